@@ -23,6 +23,10 @@ ops = {
     "%": "remainder",
     "Nu": "next-plus",
     "Nd": "next-minus",
+    "S": "scale-b",
+    "Na": "next-toward",
+    "sC": "compare-signal",
+    "rfi": "round-to-integral-value",
 
     # Custom
     "rat": "convert-to-rat",
@@ -44,9 +48,10 @@ modes = {
     "0": ROUND_DOWN,
     "<": ROUND_FLOOR,
     ">": ROUND_CEILING,
-    # ROUND_HALF_DOWN,
+    "=v": ROUND_HALF_DOWN,
     "^": ROUND_UP,
-    # ROUND_05UP,
+    "05": ROUND_05UP,
+    "=1": None,  # round-half-odd, see perform_half_odd
 }
 
 
@@ -56,7 +61,7 @@ def rand_bool():
 
 def maybe_strip_sign(x):
     if x.is_signed() and random.randint(0, 20) != 10:
-        return -x
+        return x.copy_negate()
     return x
 
 
@@ -207,6 +212,24 @@ def perform_op(op):
         r = getcontext().power(x, y, u)
         getcontext().prec -= 1
         r = +r
+    elif op == "S":
+        x = rand_dec()
+        if random.randint(0, 20) == 10:
+            y = rand_dec()
+        else:
+            y = Decimal(random.randint(-1000, 1000))
+        r = x.scaleb(y)
+    elif op == "Na":
+        x = rand_dec()
+        if random.randint(0, 10) == 5:
+            y = x
+        else:
+            y = rand_dec()
+        r = x.next_toward(y)
+    elif op == "sC":
+        x = rand_dec()
+        y = rand_dec()
+        r = x.compare_signal(y)
     elif op == "shift":
         x = rand_dec()
         y = Decimal(random.randint(-getcontext().prec, getcontext().prec))
@@ -273,6 +296,9 @@ def perform_op(op):
     elif op == "rtie":
         x = rand_dec()
         r = x.to_integral_exact()
+    elif op == "rfi":
+        x = rand_dec()
+        r = x.to_integral_value()
     elif op == "Nu":
         x = rand_dec()
         r = x.next_plus()
@@ -292,6 +318,38 @@ def perform_op(op):
     return (r, x, y, u)
 
 
+def is_odd(x):
+    return isinstance(x, Decimal) and x.is_finite() and \
+        x.as_tuple().digits[-1] % 2 == 1
+
+
+def perform_half_odd(op):
+    """Performs op with round-half-odd, which Python doesn't have.
+
+    Round-half-down and round-half-up only differ on ties, so the result is
+    whichever of them is odd.
+    """
+    ctx = getcontext()
+    prec = ctx.prec
+    state = random.getstate()
+
+    ctx.rounding = ROUND_HALF_DOWN
+    down = perform_op(op)
+    flags = ctx.flags.copy()
+
+    random.setstate(state)
+    ctx.prec = prec
+    ctx.clear_flags()
+    ctx.rounding = ROUND_HALF_UP
+    up = perform_op(op)
+
+    if str(down[0]) != str(up[0]) and is_odd(down[0]):
+        ctx.clear_flags()
+        ctx.flags.update(flags)
+        return down
+    return up
+
+
 traps = {
     Clamped: "c",
     DivisionByZero: "z",
@@ -331,12 +389,15 @@ def make_tables(items):
                 ctx = getcontext()
                 ctx.Emax = MAX_EMAX
                 ctx.Emin = MIN_EMIN
-                ctx.rounding = modes[mode]
                 ctx.prec = random.randint(1, random.randint(100, 50000))
                 ctx.clear_traps()
                 ctx.clear_flags()
 
-                r, x, y, u = perform_op(op)
+                if modes[mode] is None:
+                    r, x, y, u = perform_half_odd(op)
+                else:
+                    ctx.rounding = modes[mode]
+                    r, x, y, u = perform_op(op)
 
                 conds = ""
                 for key, value in ctx.flags.items():
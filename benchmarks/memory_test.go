@@ -0,0 +1,49 @@
+package benchmarks
+
+import (
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// The Memory benchmarks document how much memory a *decimal.Big takes up,
+// since programs often keep many of them around. Contexts with rarely used
+// settings share one decimal.Options instead of growing every Big.
+
+var gbs []decimal.Big
+
+func BenchmarkBig_Memory(b *testing.B) {
+	b.Run("new", func(b *testing.B) {
+		b.ReportAllocs()
+		var z *decimal.Big
+		for i := 0; i < b.N; i++ {
+			z = new(decimal.Big)
+		}
+		gs = z
+	})
+	b.Run("slice", func(b *testing.B) {
+		b.ReportAllocs()
+		var zs []decimal.Big
+		for i := 0; i < b.N; i++ {
+			zs = make([]decimal.Big, 1000)
+		}
+		gbs = zs
+	})
+	for _, s := range [...]struct {
+		name string
+		x    string
+	}{
+		{"compact", "1234.5678"},
+		{"inflated", "1234567890123456789012345678901234.5678"},
+	} {
+		s := s
+		b.Run("SetString/"+s.name, func(b *testing.B) {
+			b.ReportAllocs()
+			var z *decimal.Big
+			for i := 0; i < b.N; i++ {
+				z, _ = decimal.WithContext(decimal.Context128).SetString(s.x)
+			}
+			gs = z
+		})
+	}
+}
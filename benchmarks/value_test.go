@@ -0,0 +1,76 @@
+package benchmarks
+
+import (
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// The Value benchmarks document the cost of decimal.Value's immutability:
+// each operation allocates its result, whereas reusing a *decimal.Big
+// doesn't allocate at all once its big.Int (if any) has grown.
+
+var gv decimal.Value
+
+var valueOps = [...]struct {
+	name  string
+	value func(x, y decimal.Value) decimal.Value
+	big   func(c decimal.Context, z, x, y *decimal.Big) *decimal.Big
+}{
+	{"Add", decimal.Value.Add, decimal.Context.Add},
+	{"Mul", decimal.Value.Mul, decimal.Context.Mul},
+	{"Quo", decimal.Value.Quo, decimal.Context.Quo},
+}
+
+// valueArgs returns operands that fit into a uint64 ("compact") and ones that
+// need a big.Int ("inflated").
+func valueArgs() [2]struct {
+	name string
+	x, y decimal.Value
+} {
+	small := decimal.Context{Precision: 16}
+	large := decimal.Context{Precision: 40}
+	return [...]struct {
+		name string
+		x, y decimal.Value
+	}{
+		{"compact", decimal.NewValue(small, 1999, 2), decimal.NewValue(small, 3, 0)},
+		{"inflated",
+			decimal.NewValue(large, 1999, 2).Mul(decimal.NewValue(large, 1e18, 0)),
+			decimal.NewValue(large, 3e18, 0)},
+	}
+}
+
+func BenchmarkValue(b *testing.B) {
+	for _, op := range valueOps {
+		for _, args := range valueArgs() {
+			op, x, y := op, args.x, args.y
+			b.Run(op.name+"/"+args.name, func(b *testing.B) {
+				b.ReportAllocs()
+				var z decimal.Value
+				for i := 0; i < b.N; i++ {
+					z = op.value(x, y)
+				}
+				gv = z
+			})
+		}
+	}
+}
+
+// BenchmarkValue_Big performs the same operations as BenchmarkValue with a
+// reused *decimal.Big for comparison.
+func BenchmarkValue_Big(b *testing.B) {
+	for _, op := range valueOps {
+		for _, args := range valueArgs() {
+			op, x, y := op, args.x.Big(), args.y.Big()
+			b.Run(op.name+"/"+args.name, func(b *testing.B) {
+				b.ReportAllocs()
+				z := decimal.WithContext(x.Context)
+				for i := 0; i < b.N; i++ {
+					op.big(x.Context, z, x, y)
+				}
+				gs = z
+			})
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package decimal
+
+// BID32 returns x encoded as an IEEE 754-2008 decimal32 in the Binary Integer
+// Decimal format, most significant byte first.
+//
+// If x does not fit in the format it's rounded to Context32's precision and
+// exponent range using x's RoundingMode, and coefficients with too large an
+// exponent are padded with zeros. The Conditions raised along the way, like
+// Rounded, Inexact, Overflow, Underflow, Subnormal, and Clamped, are returned
+// alongside the encoding. x is not modified.
+//
+// NaN payloads keep their least significant p-1 digits, where p is the
+// precision of the format.
+func (x *Big) BID32() (b [4]byte, cond Condition) {
+	cond = ieee32.encodeBID(b[:], x)
+	return b, cond
+}
+
+// BID64 is like BID32, but encodes x as an IEEE 754-2008 decimal64 under
+// Context64.
+func (x *Big) BID64() (b [8]byte, cond Condition) {
+	cond = ieee64.encodeBID(b[:], x)
+	return b, cond
+}
+
+// BID128 is like BID32, but encodes x as an IEEE 754-2008 decimal128 under
+// Context128.
+func (x *Big) BID128() (b [16]byte, cond Condition) {
+	cond = ieee128.encodeBID(b[:], x)
+	return b, cond
+}
+
+// SetBID32 sets z to the IEEE 754-2008 decimal32 in the Binary Integer Decimal
+// format stored in b, most significant byte first, and returns z.
+//
+// Non-canonical coefficients are treated as zero and non-canonical NaN payloads
+// are discarded. The sign, quantum, and signaling bit are always preserved. z's
+// Context is not modified and no Conditions are raised.
+func (z *Big) SetBID32(b [4]byte) *Big { return ieee32.decodeBID(z, b[:]) }
+
+// SetBID64 is like SetBID32, but decodes an IEEE 754-2008 decimal64.
+func (z *Big) SetBID64(b [8]byte) *Big { return ieee64.decodeBID(z, b[:]) }
+
+// SetBID128 is like SetBID32, but decodes an IEEE 754-2008 decimal128. NaN
+// payloads that do not fit into a Payload are discarded.
+func (z *Big) SetBID128(b [16]byte) *Big { return ieee128.decodeBID(z, b[:]) }
+
+func (f ieeeFormat) encodeBID(b []byte, x *Big) Condition {
+	d, cond := f.datum(x)
+	f.bid(d).bytes(b)
+	return cond
+}
+
+func (f ieeeFormat) decodeBID(z *Big, b []byte) *Big {
+	return f.set(z, f.unbid(wordOf(b)))
+}
+
+// Combination field prefixes, the five bits following the sign bit.
+const (
+	combInf = 0x1e // 11110
+	combNaN = 0x1f // 11111
+)
+
+// bid packs d into the Binary Integer Decimal encoding.
+func (f ieeeFormat) bid(d ieeeDatum) (v word) {
+	t := f.t()
+	switch {
+	case d.form&nan != 0:
+		v = word{lo: combNaN}.lsh(f.k - 6).or(d.coeff)
+		if d.form&snan != 0 {
+			v = v.or(word{lo: 1}.lsh(f.k - 7))
+		}
+	case d.form&inf != 0:
+		v = word{lo: combInf}.lsh(f.k - 6)
+	default:
+		e := word{lo: uint64(d.exp + f.bias)}
+		if d.coeff.rsh(t + 3).isZero() {
+			// s | eeeeeeee... | ccc... (t+3 bits)
+			v = e.lsh(t + 3).or(d.coeff)
+		} else {
+			// s | 11 | eeeeeeee... | ccc... (t+1 bits) with an implicit 100
+			// prefix.
+			v = word{lo: 3}.lsh(f.k - 3).or(e.lsh(t + 1)).or(d.coeff.mask(t + 1))
+		}
+	}
+	if d.form&signbit != 0 {
+		v = v.or(word{lo: 1}.lsh(f.k - 1))
+	}
+	return v
+}
+
+// unbid unpacks the Binary Integer Decimal encoding v.
+func (f ieeeFormat) unbid(v word) (d ieeeDatum) {
+	t := f.t()
+	if v.bit(f.k-1) != 0 {
+		d.form = signbit
+	}
+	switch g := v.rsh(f.k-6).lo & 0x1f; {
+	case g == combNaN:
+		if v.bit(f.k-7) != 0 {
+			d.form |= snan
+		} else {
+			d.form |= qnan
+		}
+		d.coeff = v.mask(t)
+	case g == combInf:
+		d.form |= inf
+	case g>>3 == 3:
+		d.exp = int(v.rsh(t+1).mask(f.w+2).lo) - f.bias
+		d.coeff = word{lo: 4}.lsh(t + 1).or(v.mask(t + 1))
+	default:
+		d.exp = int(v.rsh(t+3).mask(f.w+2).lo) - f.bias
+		d.coeff = v.mask(t + 3)
+	}
+	return d
+}
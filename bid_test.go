@@ -0,0 +1,108 @@
+package decimal_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_BID(t *testing.T) {
+	for i, test := range [...]struct {
+		in   string
+		mode decimal.RoundingMode
+		size int
+		enc  string
+		out  string
+		cond decimal.Condition
+	}{
+		0:  {"1", 0, 4, "32800001", "1", 0},
+		1:  {"1", 0, 8, "31c0000000000001", "1", 0},
+		2:  {"1", 0, 16, "30400000000000000000000000000001", "1", 0},
+		3:  {"-7.50", 0, 8, "b1800000000002ee", "-7.50", 0},
+		4:  {"9999999999999999E+369", 0, 8, "77fb86f26fc0ffff", "9.999999999999999E+384", 0},
+		5:  {"Infinity", 0, 8, "7800000000000000", "Infinity", 0},
+		6:  {"-Infinity", 0, 4, "f8000000", "-Infinity", 0},
+		7:  {"NaN", 0, 8, "7c00000000000000", "NaN", 0},
+		8:  {"-sNaN", 0, 16, "fe000000000000000000000000000000", "-sNaN", 0},
+		9:  {"NaN123", 0, 4, "7c00007b", "NaN123", 0},
+		10: {"NaN12345678", 0, 4, "7c05464e", "NaN345678", 0},
+		11: {"1.23456789", 0, 4, "2f92d688", "1.234568", decimal.Inexact | decimal.Rounded},
+		12: {"1E+96", 0, 4, "5f8f4240", "1.000000E+96", decimal.Clamped},
+		13: {"1E+97", 0, 4, "78000000", "Infinity", decimal.Overflow | decimal.Inexact | decimal.Rounded},
+		14: {"1E+97", decimal.ToZero, 4, "77f8967f", "9.999999E+96", decimal.Overflow | decimal.Inexact | decimal.Rounded},
+		15: {"1E-101", 0, 4, "00000001", "1E-101", decimal.Subnormal},
+		16: {"1E-102", 0, 4, "00000000", "0E-101", decimal.Subnormal | decimal.Underflow | decimal.Inexact | decimal.Rounded | decimal.Clamped},
+		17: {"0E+200", 0, 4, "5f800000", "0E+90", decimal.Clamped},
+		18: {"1234567890123456789012345678901234", 0, 16, "30403cde6fff9732de825cd07e96aff2", "1234567890123456789012345678901234", 0},
+		19: {"-0", 0, 8, "b1c0000000000000", "-0", 0},
+	} {
+		x, ok := new(decimal.Big).SetString(test.in)
+		if !ok {
+			t.Fatalf("#%d: invalid input %q", i, test.in)
+		}
+		x.Context.RoundingMode = test.mode
+
+		var (
+			b    []byte
+			cond decimal.Condition
+			z    decimal.Big
+		)
+		switch test.size {
+		case 4:
+			a, c := x.BID32()
+			b, cond = a[:], c
+			z.SetBID32(a)
+		case 8:
+			a, c := x.BID64()
+			b, cond = a[:], c
+			z.SetBID64(a)
+		case 16:
+			a, c := x.BID128()
+			b, cond = a[:], c
+			z.SetBID128(a)
+		}
+		if got := hex.EncodeToString(b); got != test.enc {
+			t.Fatalf("#%d: BID(%q): wanted %s, got %s", i, test.in, test.enc, got)
+		}
+		if cond != test.cond {
+			t.Fatalf("#%d: BID(%q): wanted %q, got %q", i, test.in, test.cond, cond)
+		}
+		if got := z.String(); got != test.out {
+			t.Fatalf("#%d: SetBID(%s): wanted %q, got %q", i, test.enc, test.out, got)
+		}
+	}
+}
+
+func TestBig_SetBIDNonCanonical(t *testing.T) {
+	// decimal32 with the large coefficient form always exceeds 10**7 - 1.
+	var z decimal.Big
+	z.SetBID32([4]byte{0x6c, 0xbf, 0xff, 0xff})
+	if z.Sign() != 0 || z.Scale() != 0 {
+		t.Fatalf("wanted 0, got %s", &z)
+	}
+
+	// decimal64 NaN with a payload >= 10**15.
+	z.SetBID64([8]byte{0x7c, 0x03, 0x8d, 0x7e, 0xa4, 0xc6, 0x80, 0x00})
+	if !z.IsNaN(+1) || z.Payload() != 0 {
+		t.Fatalf("wanted NaN, got %s", &z)
+	}
+}
+
+func TestBig_BIDRoundTrip(t *testing.T) {
+	for i, s := range randDecs {
+		x := decimal.WithContext(decimal.Context128)
+		if _, ok := x.SetString(s); !ok {
+			t.Fatalf("#%d: invalid input %q", i, s)
+		}
+		b, cond := x.BID128()
+		var z decimal.Big
+		z.SetBID128(b)
+		if cond&decimal.Rounded == 0 && (z.Cmp(x) != 0 || z.Scale() != x.Scale()) {
+			t.Fatalf("#%d: wanted %s, got %s", i, x, &z)
+		}
+		if b2, _ := z.BID128(); b2 != b {
+			t.Fatalf("#%d: %s: re-encoding changed the bits", i, &z)
+		}
+	}
+}
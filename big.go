@@ -48,8 +48,10 @@ type Big struct {
 	//   number × 10**exp = number ×  10**-scale
 	exp int
 
-	// precision is the current precision.
-	precision int
+	// precision is the current precision. It's an int32 so that it shares a
+	// word with form, which keeps Big small. Coefficients can't have more
+	// digits than that on 32-bit platforms anyway.
+	precision int32
 
 	// form indicates whether a decimal is a finite number, an infinity, or a
 	// NaN value and whether it's signed or not.
@@ -143,6 +145,12 @@ const (
 	reduction
 	quointprec
 	remprec
+	roundunnec
+	rounding
+	roundincinv
+	roundincprec
+	toomanydigits
+	toolargeshift
 )
 
 var payloads = [...]string{
@@ -175,6 +183,12 @@ var payloads = [...]string{
 	reduction:      "reduction with NaN as an operand",
 	quointprec:     "result of integer division was larger than the desired precision",
 	remprec:        "result of remainder operation was larger than the desired precision",
+	roundunnec:     "result is inexact, but must be exact",
+	rounding:       "rounding with NaN as an operand",
+	roundincinv:    "rounding to an increment that is not finite and greater than zero",
+	roundincprec:   "rounding to an increment exceeds working precision",
+	toomanydigits:  "result would have more digits than Options.MaxDigits",
+	toolargeshift:  "result would need a scale change larger than Options.MaxScaleDiff",
 }
 
 func (p Payload) String() string {
@@ -209,21 +223,24 @@ func (z *Big) checkNaNs(x, y *Big, op Payload) bool {
 		return false
 	}
 
-	form := qnan
+	// The result is the first signaling NaN or, if there isn't one, the
+	// first quiet NaN, made quiet. It keeps its payload so diagnostics
+	// propagate; NaNs without one are given op.
+	src := y
 	var cond Condition
 	if f&snan != 0 {
 		cond = InvalidOperation
 		if x.form&snan != 0 {
-			form |= (x.form & signbit)
-		} else {
-			form |= (y.form & signbit)
+			src = x
 		}
 	} else if x.form&nan != 0 {
-		form |= (x.form & signbit)
-	} else {
-		form |= (y.form & signbit)
+		src = x
+	}
+	p := Payload(src.compact)
+	if p == 0 {
+		p = op
 	}
-	z.setNaN(cond, form, op)
+	z.setNaN(cond, qnan|src.form&signbit, p)
 	return true
 }
 
@@ -231,22 +248,7 @@ func (z *Big) xflow(exp int, over, neg bool) *Big {
 	// over == overflow
 	// neg == intermediate result < 0
 	if over {
-		// TODO(eric): actually choose the largest finite number in the current
-		// precision. This is legacy now.
-		//
-		// NOTE(eric): in some situations, the decimal library tells us to set
-		// z to "the largest finite number that can be represented in the
-		// current precision..." Use signed Infinity instead.
-		//
-		// Because of the logic above, every rounding mode works out to the
-		// following.
-		if neg {
-			z.form = ninf
-		} else {
-			z.form = pinf
-		}
-		z.Context.Conditions |= Overflow | Inexact | Rounded
-		return z
+		return z.Context.overflow(z, neg)
 	}
 
 	var sign form
@@ -265,7 +267,8 @@ func (x *Big) isInflated() bool { return !x.isCompact() }
 func (x *Big) isSpecial() bool  { return x.form&(inf|nan) != 0 }
 
 func (x *Big) adjusted() int { return (x.exp + x.Precision()) - 1 }
-func (c Context) etiny() int { return MinScale - (precision(c) - 1) }
+func (c Context) etiny() int { return c.MinScale() - (precision(c) - 1) }
+func (c Context) etop() int  { return c.MaxScale() - (precision(c) - 1) }
 
 // Abs sets z to the absolute value of x and returns z.
 func (z *Big) Abs(x *Big) *Big {
@@ -281,6 +284,33 @@ func (z *Big) Abs(x *Big) *Big {
 // Add sets z to x + y and returns z.
 func (z *Big) Add(x, y *Big) *Big { return z.Context.Add(z, x, y) }
 
+// Append appends to buf the string form of x, as generated by x.Text, and
+// returns the extended buffer. It doesn't allocate if buf has enough room and
+// x's coefficient fits into a uint64.
+func (x *Big) Append(buf []byte, fmt byte, prec int) []byte {
+	if debug {
+		x.validate()
+	}
+
+	f := formatter{prec: prec, width: noWidth}
+	if prec < 0 {
+		f.prec = x.Precision()
+	} else if fmt == 'f' {
+		// 'f''s precision means "number of digits after the radix"
+		f.prec = x.fracPrec(prec)
+	}
+	switch fmt {
+	case 's':
+		return f.format(buf, x, normal, sciE[x.Context.OperatingMode])
+	case 'e', 'E':
+		return f.format(buf, x, sci, fmt)
+	case 'f', 'g':
+		return f.format(buf, x, plain, 0)
+	default:
+		return append(buf, '%', fmt)
+	}
+}
+
 // Class returns the ``class'' of x, which is one of the following:
 //
 //  sNaN
@@ -456,7 +486,7 @@ func (z *Big) Copy(x *Big) *Big {
 // copyAbs sets z to a copy of |x| and returns z.
 func (z *Big) copyAbs(x *Big) *Big {
 	if z != x {
-		z.precision = x.Precision()
+		z.precision = int32(x.Precision())
 		z.exp = x.exp
 		z.compact = x.compact
 		if x.IsFinite() && x.isInflated() {
@@ -566,6 +596,9 @@ func (x *Big) Float(z *big.Float) *big.Float {
 //
 // 	%s: -dddd.dd or -d.dddd±edd, depending on x
 // 	%d: same as %s
+// 	%#s: -dddd.dd or -ddd.dddd±edd, depending on x, with an exponent that's a
+// 	     multiple of three (see EngString)
+// 	%#d: same as %#s
 // 	%v: same as %s
 // 	%e: -d.dddd±edd
 // 	%E: -d.dddd±Edd
@@ -578,8 +611,8 @@ func (x *Big) Float(z *big.Float) *big.Float {
 // following the radix.
 //
 // Format honors all flags (such as '+' and ' ') in the same manner as the fmt
-// package, except for '#'. Unless used in conjunction with %v, %q, or %p, the
-// '#' flag will be ignored; decimals have no defined hexadeximal or octal
+// package, except for '#'. Unless used in conjunction with %s, %d, %v, %q, or
+// %p, the '#' flag will be ignored; decimals have no defined hexadeximal or octal
 // representation.
 //
 // %+v, %#v, %T, %#p, and %p all honor the formats specified in the fmt
@@ -609,29 +642,24 @@ func (x *Big) Format(s fmt.State, c rune) {
 		e       = sciE[x.Context.OperatingMode]
 	)
 
-	// If we need to left pad then we need to first write our string into an
-	// empty buffer.
-	tmpbuf := lpZero || lpSpace
-	if tmpbuf {
-		b := new(strings.Builder)
-		b.Grow(x.Precision())
-		f.w = b
-	} else {
-		f.w = stateWrapper{s}
-	}
-
 	if plus {
 		f.sign = '+'
 	} else if space {
 		f.sign = ' '
 	}
 
+	var b []byte
+
 	// noE is a placeholder for formats that do not use scientific notation
 	// and don't require 'e' or 'E'
 	const noE = 0
 	switch c {
 	case 's', 'd':
-		f.format(x, normal, e)
+		if hash {
+			b = f.format(b, x, eng, e)
+		} else {
+			b = f.format(b, x, normal, e)
+		}
 	case 'q':
 		// The fmt package's docs specify that the '+' flag
 		// "guarantee[s] ASCII-only output for %q (%+q)"
@@ -643,38 +671,26 @@ func (x *Big) Format(s fmt.State, c rune) {
 		if hash {
 			quote = '`'
 		}
-		f.WriteByte(quote)
-		f.format(x, normal, e)
-		f.WriteByte(quote)
+		b = append(b, quote)
+		b = f.format(b, x, normal, e)
+		b = append(b, quote)
 	case 'e', 'E':
-		f.format(x, sci, byte(c))
+		b = f.format(b, x, sci, byte(c))
 	case 'f', 'F':
-		if !hasPrec {
-			prec = 0
-		} else {
-
+		if hasPrec {
 			// %f's precision means "number of digits after the radix"
-			if x.exp > 0 {
-				f.prec += x.Precision()
-			} else {
-				if adj := x.exp + x.Precision(); adj > -f.prec {
-					f.prec += adj
-				} else {
-					f.prec = -f.prec
-				}
-			}
+			f.prec = x.fracPrec(f.prec)
 		}
-
-		f.format(x, plain, noE)
+		b = f.format(b, x, plain, noE)
 	case 'g', 'G':
 		// %g's precision means "number of significant digits"
-		f.format(x, plain, noE)
+		b = f.format(b, x, plain, noE)
 
 	// Make sure we return from the following two cases.
 	case 'v':
 		// %v == %s
 		if !hash && !plus {
-			f.format(x, normal, e)
+			b = f.format(b, x, normal, e)
 			break
 		}
 
@@ -686,7 +702,7 @@ func (x *Big) Format(s fmt.State, c rune) {
 			unscaled  big.Int
 			compact   uint64
 			exp       int
-			precision int
+			precision int32
 			form      form
 		}
 		specs := ""
@@ -710,20 +726,22 @@ func (x *Big) Format(s fmt.State, c rune) {
 	}
 
 	// Need padding out to width.
-	if f.n < int64(width) {
-		switch pad := int64(width) - f.n; {
-		case dash:
-			io.CopyN(s, spaceReader{}, pad)
-		case lpZero:
-			io.CopyN(s, zeroReader{}, pad)
-		case lpSpace:
-			io.CopyN(s, spaceReader{}, pad)
-		}
-	}
-
-	if tmpbuf {
-		// fmt's internal state type implements stringWriter I think.
-		io.WriteString(s, f.w.(*strings.Builder).String())
+	var pad int64
+	if len(b) < width {
+		pad = int64(width - len(b))
+	}
+	switch {
+	case dash:
+		s.Write(b)
+		io.CopyN(s, spaceReader{}, pad)
+	case lpZero:
+		io.CopyN(s, zeroReader{}, pad)
+		s.Write(b)
+	case lpSpace:
+		io.CopyN(s, spaceReader{}, pad)
+		s.Write(b)
+	default:
+		s.Write(b)
 	}
 }
 
@@ -827,12 +845,12 @@ func (x *Big) IsFinite() bool { return x.form & ^signbit == 0 }
 
 // IsNormal returns true if x is normal.
 func (x *Big) IsNormal() bool {
-	return x.IsFinite() && x.adjusted() >= x.Context.minScale()
+	return x.IsFinite() && x.adjusted() >= x.Context.MinScale()
 }
 
 // IsSubnormal returns true if x is subnormal.
 func (x *Big) IsSubnormal() bool {
-	return x.IsFinite() && x.adjusted() < x.Context.minScale()
+	return x.IsFinite() && x.adjusted() < x.Context.MinScale()
 }
 
 // IsInf returns true if x is an infinity according to sign.
@@ -903,14 +921,12 @@ func (x *Big) MarshalText() ([]byte, error) {
 	if debug {
 		x.validate()
 	}
-	var (
-		b = new(bytes.Buffer)
-		f = formatter{w: b, prec: x.Precision(), width: noWidth}
-		e = sciE[x.Context.OperatingMode]
-	)
-	b.Grow(x.Precision())
-	f.format(x, normal, e)
-	return b.Bytes(), nil
+	if x.IsNaN(0) {
+		// Use the GDA format regardless of OperatingMode so the payload and
+		// signal survive a round trip.
+		return []byte(x.specialString()), nil
+	}
+	return x.appendFormat(nil, normal), nil
 }
 
 // Mul sets z to x * y and returns z.
@@ -941,8 +957,17 @@ func (z *Big) Neg(x *Big) *Big {
 //  New(-1, 0)   // -1
 //  New(3, -10)  // 30 000 000 000
 //
+// The result uses the default Context. See SetDefaultContext.
 func New(value int64, scale int) *Big {
-	return new(Big).SetMantScale(value, scale)
+	return new(Big).newDefault(value, scale)
+}
+
+// newDefault sets z, a new Big, to value and scale with the default Context and
+// returns z. It's separate from New so that New is small enough to be inlined,
+// which lets its result be allocated on the stack.
+func (z *Big) newDefault(value int64, scale int) *Big {
+	z.Context = DefaultContext()
+	return z.SetMantScale(value, scale)
 }
 
 // Payload returns the payload of x, provided x is a NaN value. If x is not a
@@ -965,7 +990,7 @@ func (x *Big) Precision() int {
 	if x.precision == 0 {
 		return 1
 	}
-	return x.precision
+	return int(x.precision)
 }
 
 // Quantize sets z to the number equal in value and sign to z with the scale, n.
@@ -1047,19 +1072,56 @@ func (z *Big) Reduce() *Big { return z.Context.Reduce(z) }
 // Rem sets z to the remainder x % y. See QuoRem for more details.
 func (z *Big) Rem(x, y *Big) *Big { return z.Context.Rem(z, x, y) }
 
+// RemNear sets z to the remainder x - y×n, where n is the integer nearest to
+// x / y. See Context.RemNear for more details.
+func (z *Big) RemNear(x, y *Big) *Big { return z.Context.RemNear(z, x, y) }
+
 // Round rounds z down to n digits of precision and returns z. The result is
 // undefined if z is not finite. No rounding will occur if n <= 0. The result of
 // Round will always be within the interval [⌊10**x⌋, z] where x = the precision
 // of z.
 func (z *Big) Round(n int) *Big {
-	ctx := z.Context
+	ctx := z.Context.withoutFixedScale()
 	ctx.Precision = n
 	return ctx.Round(z)
 }
 
+// RoundDigits rounds z to n significant digits using m, rather than z's
+// RoundingMode, and returns z. No rounding will occur if n <= 0.
+func (z *Big) RoundDigits(n int, m RoundingMode) *Big {
+	if n <= 0 {
+		return z
+	}
+	ctx := z.Context.withoutFixedScale()
+	ctx.Precision = n
+	ctx.RoundingMode = m
+	return ctx.Round(z)
+}
+
+// RoundIncrement rounds z to an integral multiple of inc using m, rather than
+// z's RoundingMode, and returns z. See Context.RoundIncrement for more
+// details.
+func (z *Big) RoundIncrement(inc *Big, m RoundingMode) *Big {
+	ctx := z.Context
+	ctx.RoundingMode = m
+	return ctx.RoundIncrement(z, inc)
+}
+
+// RoundScale rounds z to n digits after the decimal point using m, rather than
+// z's RoundingMode, and returns z. See Context.RoundScale for more details.
+func (z *Big) RoundScale(n int, m RoundingMode) *Big {
+	ctx := z.Context
+	ctx.RoundingMode = m
+	return ctx.RoundScale(z, n)
+}
+
 // RoundToInt rounds z down to an integral value.
 func (z *Big) RoundToInt() *Big { return z.Context.RoundToInt(z) }
 
+// RoundToIntegralValue is like RoundToInt, but it never raises Inexact or
+// Rounded.
+func (z *Big) RoundToIntegralValue() *Big { return z.Context.RoundToIntegralValue(z) }
+
 // Scale returns x's scale.
 func (x *Big) Scale() int { return -x.exp }
 
@@ -1081,7 +1143,7 @@ func (z *Big) setShared(x *Big) *Big {
 	}
 
 	if z != x {
-		z.precision = x.Precision()
+		z.precision = int32(x.Precision())
 		z.compact = x.compact
 		z.form = x.form
 		z.exp = x.exp
@@ -1100,7 +1162,7 @@ func (z *Big) SetBigMantScale(value *big.Int, scale int) *Big {
 
 	z.unscaled.Abs(value)
 	z.compact = c.Inflated
-	z.precision = arith.BigLength(value)
+	z.precision = int32(arith.BigLength(value))
 
 	if z.unscaled.IsUint64() {
 		if v := z.unscaled.Uint64(); v != c.Inflated {
@@ -1145,11 +1207,11 @@ func (z *Big) SetFloat(x *big.Float) *Big {
 
 	if mant, acc := x0.Uint64(); acc == big.Exact {
 		z.compact = mant
-		z.precision = arith.Length(mant)
+		z.precision = int32(arith.Length(mant))
 	} else {
 		z.compact = c.Inflated
 		x0.Int(&z.unscaled)
-		z.precision = arith.BigLength(&z.unscaled)
+		z.precision = int32(arith.BigLength(&z.unscaled))
 	}
 	z.form = finite
 	if neg {
@@ -1274,6 +1336,17 @@ func (z *Big) SetNaN(signal bool) *Big {
 	return z
 }
 
+// SetNaNPayload is like SetNaN, but sets z's payload to p. The payload
+// propagates through arithmetic, so it can be used to tag where a NaN came
+// from, and is written as the NaN's digits, e.g. "NaN123", by String and
+// MarshalText. Note that NaNs created by this package have payloads too, and
+// Payload.String describes them regardless of who set the payload.
+func (z *Big) SetNaNPayload(signal bool, p Payload) *Big {
+	z.SetNaN(signal)
+	z.compact = uint64(p)
+	return z
+}
+
 // SetRat sets z to to the possibly rounded value of x and return z.
 func (z *Big) SetRat(x *big.Rat) *Big {
 	if x.IsInt() {
@@ -1285,8 +1358,13 @@ func (z *Big) SetRat(x *big.Rat) *Big {
 	return z.Quo(&num, &denom)
 }
 
-// SetScale sets z's scale to scale and returns z.
+// SetScale sets z's scale to scale and returns z. If the change in scale
+// exceeds the MaxScaleDiff of z's Options, z is set to NaN and
+// InsufficientStorage is raised.
 func (z *Big) SetScale(scale int) *Big {
+	if z.IsFinite() && z.Context.tooLargeShift(z.exp+scale) {
+		return z.setNaN(InsufficientStorage, qnan, toolargeshift)
+	}
 	z.exp = -scale
 	return z
 }
@@ -1311,9 +1389,16 @@ var Regexp = regexp.MustCompile(`(?i)(([+-]?(\d+\.\d*|\.?\d+)([eE][+-]?\d+)?)|(i
 // Each value may be preceded by an optional sign, ``-'' or ``+''. ``Inf'' and
 // ``NaN'' map to ``+Inf'' and ``qNaN'', respectively. NaN values may have
 // optional diagnostic information, represented as trailing digits; for example,
-// ``NaN123''. These digits are otherwise ignored but are included for
-// robustness.
+// ``NaN123''. These digits are kept as the NaN's payload.
+//
+// If z's Context is the zero Context, ignoring its Conditions, it's replaced
+// by the default Context. See SetDefaultContext.
 func (z *Big) SetString(s string) (*Big, bool) {
+	if z.Context.isZero() {
+		cond := z.Context.Conditions
+		z.Context = DefaultContext()
+		z.Context.Conditions = cond
+	}
 	if err := z.scan(strings.NewReader(s)); err != nil {
 		return nil, false
 	}
@@ -1322,7 +1407,7 @@ func (z *Big) SetString(s string) (*Big, bool) {
 
 func (z *Big) setTriple(compact uint64, sign form, exp int) *Big {
 	z.compact = compact
-	z.precision = arith.Length(compact)
+	z.precision = int32(arith.Length(compact))
 	z.exp = exp
 	z.form = finite | sign
 	return z
@@ -1342,7 +1427,7 @@ func (z *Big) SetUint64(x uint64) *Big {
 	if x == c.Inflated {
 		z.unscaled.SetUint64(x)
 	}
-	z.precision = arith.Length(x)
+	z.precision = int32(arith.Length(x))
 	z.exp = 0
 	z.form = finite
 	return z
@@ -1397,18 +1482,48 @@ func (x *Big) Signbit() bool {
 // discussed in the Format method's documentation. Special cases depend on the
 // OperatingMode.
 func (x *Big) String() string {
-	var (
-		b = new(strings.Builder)
-		f = formatter{w: b, prec: x.Precision(), width: noWidth}
-		e = sciE[x.Context.OperatingMode]
-	)
-	b.Grow(x.Precision())
-	f.format(x, normal, e)
-	return b.String()
+	var buf [32]byte
+	return string(x.appendFormat(buf[:0], normal))
 }
 
 var _ fmt.Stringer = (*Big)(nil)
 
+// EngString returns the string representation of x using engineering notation
+// if an exponent is needed. It's the same as String, except that the exponent
+// is a multiple of three, so there are one to three digits before the decimal
+// point. For example, 1.25E+4 is formatted as 12.5E+3.
+//
+// Trailing zeros are added if x's coefficient is too short, and zeros are
+// formatted with their exponent increased to the next multiple of three and
+// zeros added after the decimal point instead, so 0E+1 is formatted as
+// 0.00E+3. This is the General Decimal Arithmetic specification's
+// to-engineering-string conversion.
+func (x *Big) EngString() string {
+	var buf [32]byte
+	return string(x.appendFormat(buf[:0], eng))
+}
+
+// Text converts x to a string according to the format fmt and the precision
+// prec, like strconv.FormatFloat. The formats are the same as Format's verbs:
+//
+//   's' -dddd.dd or -d.dddd±Edd, depending on x (same as String)
+//   'e' -d.dddd±edd
+//   'E' -d.dddd±Edd
+//   'f' -dddd.dd
+//   'g' same as 'f'
+//
+// For 's', 'e', 'E' and 'g', prec is the number of significant digits. For
+// 'f', it's the number of digits following the radix. A negative prec uses
+// all of x's digits. If rounding is needed, x's RoundingMode is used.
+//
+// Text formats special values and uses the exponent character 'E' or 'e'
+// according to x's OperatingMode, like String. An invalid format character
+// results in '%' followed by that character.
+func (x *Big) Text(fmt byte, prec int) string {
+	var buf [32]byte
+	return string(x.Append(buf[:0], fmt, prec))
+}
+
 // Sub sets z to x - y and returns z.
 func (z *Big) Sub(x, y *Big) *Big { return z.Context.Sub(z, x, y) }
 
@@ -1433,7 +1548,7 @@ func (x *Big) validate() {
 				unscaled  big.Int
 				compact   uint64
 				exp       int
-				precision int
+				precision int32
 				form      form
 			}
 			fmt.Printf("%#v\n", (*Big)(x))
@@ -1449,7 +1564,7 @@ func (x *Big) validate() {
 			if x.unscaled.Sign() < 0 {
 				panic("x.unscaled.Sign() < 0")
 			}
-			if bl, xp := arith.BigLength(&x.unscaled), x.precision; bl != xp {
+			if bl, xp := arith.BigLength(&x.unscaled), int(x.precision); bl != xp {
 				panic(fmt.Sprintf("BigLength (%d) != x.Precision (%d)", bl, xp))
 			}
 		}
@@ -3,6 +3,7 @@ package decimal
 import (
 	"math"
 	"math/big"
+	"math/rand"
 
 	"github.com/ericlagergren/decimal/internal/arith"
 	"github.com/ericlagergren/decimal/internal/arith/checked"
@@ -11,6 +12,10 @@ import (
 
 // Add sets z to x + y and returns z.
 func (c Context) Add(z, x, y *Big) *Big {
+	if c.hook() != nil {
+		c.watched("Add", func(c Context) { c.Add(z, x, y) }, z, x, y)
+		return z
+	}
 	if debug {
 		x.validate()
 		y.validate()
@@ -20,7 +25,11 @@ func (c Context) Add(z, x, y *Big) *Big {
 	}
 
 	if x.IsFinite() && y.IsFinite() {
-		z.form = finite | c.add(z, x, x.form, y, y.form)
+		sign, ok := c.add(z, x, x.form, y, y.form)
+		if !ok {
+			return z
+		}
+		z.form = finite | sign
 		return c.round(z)
 	}
 
@@ -46,7 +55,10 @@ func (c Context) Add(z, x, y *Big) *Big {
 	return z.Set(y)
 }
 
-func (c Context) add(z *Big, x *Big, xn form, y *Big, yn form) (sign form) {
+// add sets z to x + y, with the signs xn and yn, and returns the sign of the
+// result. If aligning x and y would exceed c's MaxScaleDiff or MaxDigits, it
+// sets z to NaN and returns false.
+func (c Context) add(z *Big, x *Big, xn form, y *Big, yn form) (sign form, ok bool) {
 	hi, lo := x, y
 	hineg, loneg := xn, yn
 	if hi.exp < lo.exp {
@@ -55,7 +67,17 @@ func (c Context) add(z *Big, x *Big, xn form, y *Big, yn form) (sign form) {
 	}
 
 	if sign, ok := c.tryTinyAdd(z, hi, hineg, lo, loneg); ok {
-		return sign
+		return sign, true
+	}
+
+	shift := hi.exp - lo.exp
+	if c.tooLargeShift(shift) {
+		z.setNaN(InsufficientStorage, qnan, toolargeshift)
+		return 0, false
+	}
+	if c.tooManyDigits(hi.Precision() + shift) {
+		z.setNaN(InsufficientStorage, qnan, toomanydigits)
+		return 0, false
 	}
 
 	if hi.isCompact() {
@@ -70,14 +92,16 @@ func (c Context) add(z *Big, x *Big, xn form, y *Big, yn form) (sign form) {
 		sign = c.addBig(z, &hi.unscaled, hineg, &lo.unscaled, loneg, uint64(hi.exp-lo.exp))
 	}
 	z.exp = lo.exp
-	return sign
+	return sign, true
 }
 
 // tryTinyAdd returns true if hi + lo requires a huge shift that will produce
 // the same results as a smaller shift. E.g., 3 + 0e+9999999999999999 with a
 // precision of 5 doesn't need to be shifted by a large number.
 func (c Context) tryTinyAdd(z *Big, hi *Big, hineg form, lo *Big, loneg form) (sign form, ok bool) {
-	if hi.compact == 0 {
+	// The shortcut depends on the precision, which doesn't bound the digits
+	// that a fixed scale keeps.
+	if _, ok := c.fixedScale(); ok || hi.compact == 0 {
 		return 0, false
 	}
 
@@ -85,6 +109,12 @@ func (c Context) tryTinyAdd(z *Big, hi *Big, hineg form, lo *Big, loneg form) (s
 	if hp, zp := hi.Precision(), precision(c); hp <= zp {
 		exp += hp - zp - 1
 	}
+	if c.RoundingMode == Stochastic {
+		// Stochastic rounds up with a probability that depends on every
+		// discarded digit, so move the sticky digit below the resolution of
+		// its random numbers, 2**-64 of a unit in the last place.
+		exp -= 20
+	}
 
 	if lo.adjusted() >= exp {
 		return 0, false
@@ -115,7 +145,7 @@ func (c Context) addCompact(z *Big, hi uint64, hineg form, lo uint64, loneg form
 				if z0 == cst.Inflated {
 					z.unscaled.SetUint64(cst.Inflated)
 				}
-				z.precision = arith.Length(z.compact)
+				z.precision = int32(arith.Length(z.compact))
 			} else {
 				arith.Set128(&z.unscaled, z1, z0)
 				z.precision = 20
@@ -140,7 +170,7 @@ func (c Context) addCompact(z *Big, hi uint64, hineg form, lo uint64, loneg form
 			}
 			return 0
 		}
-		z.precision = arith.Length(z.compact)
+		z.precision = int32(arith.Length(z.compact))
 		return sign
 	}
 
@@ -148,7 +178,7 @@ func (c Context) addCompact(z *Big, hi uint64, hineg form, lo uint64, loneg form
 		hi := z.unscaled.SetUint64(hi)
 		hi = checked.MulBigPow10(hi, hi, shift)
 		if hineg == loneg {
-			z.precision = arith.BigLength(arith.Add(&z.unscaled, hi, lo))
+			z.precision = int32(arith.BigLength(arith.Add(&z.unscaled, hi, lo)))
 			z.compact = cst.Inflated
 		} else {
 			arith.Sub(&z.unscaled, hi, lo)
@@ -177,7 +207,7 @@ func (c Context) addMixed(z *Big, x *big.Int, xneg form, xs int, y uint64, yn fo
 
 	if xneg == yn {
 		arith.Add(&z.unscaled, x, y)
-		z.precision = arith.BigLength(&z.unscaled)
+		z.precision = int32(arith.BigLength(&z.unscaled))
 		z.compact = cst.Inflated
 	} else {
 		// x > y
@@ -195,7 +225,7 @@ func (c Context) addBig(z *Big, hi *big.Int, hineg form, lo *big.Int, loneg form
 	if hineg == loneg {
 		z.unscaled.Add(hi, lo)
 		z.compact = cst.Inflated
-		z.precision = arith.BigLength(&z.unscaled)
+		z.precision = int32(arith.BigLength(&z.unscaled))
 		return hineg
 	}
 
@@ -221,8 +251,47 @@ func (c Context) addBig(z *Big, hi *big.Int, hineg form, lo *big.Int, loneg form
 	return sign
 }
 
+// CmpSignal compares x and y like x.Cmp(y) and returns the result and true.
+// If either x or y is a NaN, quiet or signaling, it instead raises
+// InvalidOperation in c and returns 0 and false. It doesn't modify x or y.
+//
+// Unlike most Context methods, CmpSignal has a pointer receiver because there
+// is no result to record the Condition in. z.Context.CmpSignal(x, y) raises it
+// in z's Context.
+func (c *Context) CmpSignal(x, y *Big) (int, bool) {
+	if debug {
+		x.validate()
+		y.validate()
+	}
+	if (x.form|y.form)&nan == 0 {
+		return cmp(x, y, false), true
+	}
+	c.Conditions |= InvalidOperation
+	if h := c.hook(); h != nil {
+		o := *c.Options
+		o.Hook = nil
+		e := Event{
+			Op:      "CmpSignal",
+			Context: *c,
+			Args:    []*Big{new(Big).Copy(x), new(Big).Copy(y)},
+			Result:  new(Big).SetNaN(false),
+			Raised:  InvalidOperation,
+		}
+		e.Context.Options = &o
+		h.Notify(e)
+	}
+	if c.OperatingMode == Go {
+		panic(ErrNaN{Msg: c.Conditions.String()})
+	}
+	return 0, false
+}
+
 // FMA sets z to (x * y) + u without any intermediate rounding.
 func (c Context) FMA(z, x, y, u *Big) *Big {
+	if c.hook() != nil {
+		c.watched("FMA", func(c Context) { c.FMA(z, x, y, u) }, z, x, y, u)
+		return z
+	}
 	if z.invalidContext(c) {
 		return z
 	}
@@ -231,17 +300,24 @@ func (c Context) FMA(z, x, y, u *Big) *Big {
 	z0 := z
 	if z == u {
 		z0 = WithContext(c)
+		z0.Context.Conditions = 0
 	}
 	c.mul(z0, x, y)
-	if z0.Context.Conditions&InvalidOperation != 0 {
-		return z.setShared(z0)
+	if z0.Context.Conditions&InvalidOperation == 0 {
+		c.Add(z0, z0, u)
+	}
+	if z0 != z {
+		z.Context.Conditions |= z0.Context.Conditions
 	}
-	c.Add(z0, z0, u)
 	return z.setShared(z0)
 }
 
 // Mul sets z to x * y and returns z.
 func (c Context) Mul(z, x, y *Big) *Big {
+	if c.hook() != nil {
+		c.watched("Mul", func(c Context) { c.Mul(z, x, y) }, z, x, y)
+		return z
+	}
 	if z.invalidContext(c) {
 		return z
 	}
@@ -270,7 +346,7 @@ func (c Context) mul(z, x, y *Big) *Big {
 					if z0 == cst.Inflated {
 						z.unscaled.SetUint64(cst.Inflated)
 					}
-					z.precision = arith.Length(z0)
+					z.precision = int32(arith.Length(z0))
 					return z
 				}
 				arith.Set128(&z.unscaled, z1, z0)
@@ -308,6 +384,10 @@ func (c Context) mul(z, x, y *Big) *Big {
 
 // Quantize sets z to the number equal in value and sign to z with the scale, n.
 func (c Context) Quantize(z *Big, n int) *Big {
+	if c.hook() != nil {
+		c.watched("Quantize", func(c Context) { c.Quantize(z, n) }, z, z, New(1, n))
+		return z
+	}
 	if debug {
 		z.validate()
 	}
@@ -324,7 +404,7 @@ func (c Context) Quantize(z *Big, n int) *Big {
 		return z
 	}
 
-	if n > c.maxScale() || n < c.etiny() {
+	if n > c.MaxScale() || n < c.etiny() {
 		return z.setNaN(InvalidOperation, qnan, quantminmax)
 	}
 
@@ -334,9 +414,15 @@ func (c Context) Quantize(z *Big, n int) *Big {
 	}
 
 	shift := z.exp - n
+	if c.tooLargeShift(shift) {
+		return z.setNaN(InsufficientStorage, qnan, toolargeshift)
+	}
 	if z.Precision()+shift > precision(c) {
 		return z.setNaN(InvalidOperation, qnan, quantprec)
 	}
+	if c.tooManyDigits(z.Precision() + shift) {
+		return z.setNaN(InsufficientStorage, qnan, toomanydigits)
+	}
 
 	z.exp = n
 	if shift == 0 {
@@ -356,7 +442,7 @@ func (c Context) Quantize(z *Big, n int) *Big {
 			}
 			// shift < 0
 		} else if yc, ok := arith.Pow10(uint64(-shift)); ok {
-			z.quo(m, z.compact, neg, yc, 0)
+			z.quo(m, c.source(), z.compact, neg, yc, 0)
 			return z
 		}
 		z.unscaled.SetUint64(z.compact)
@@ -365,16 +451,20 @@ func (c Context) Quantize(z *Big, n int) *Big {
 
 	if shift > 0 {
 		checked.MulBigPow10(&z.unscaled, &z.unscaled, uint64(shift))
-		z.precision = arith.BigLength(&z.unscaled)
+		z.precision = int32(arith.BigLength(&z.unscaled))
 	} else {
 		var r big.Int
-		z.quoBig(m, &z.unscaled, neg, arith.BigPow10(uint64(-shift)), 0, &r)
+		z.quoBig(m, c.source(), &z.unscaled, neg, arith.BigPow10(uint64(-shift)), 0, &r)
 	}
 	return z
 }
 
 // Quo sets z to x / y and returns z.
 func (c Context) Quo(z, x, y *Big) *Big {
+	if c.hook() != nil {
+		c.watched("Quo", func(c Context) { c.Quo(z, x, y) }, z, x, y)
+		return z
+	}
 	if debug {
 		x.validate()
 		y.validate()
@@ -414,6 +504,9 @@ func (c Context) Quo(z, x, y *Big) *Big {
 		z.Context.Conditions |= DivisionByZero
 		return z.SetInf(sign != 0)
 	}
+	if scale, ok := c.fixedScale(); ok {
+		return c.quoScale(z, x, y, scale)
+	}
 	if x.compact == 0 {
 		// 0 / y
 		return c.fix(z.setZero(sign, x.exp-y.exp))
@@ -437,41 +530,28 @@ func (c Context) Quo(z, x, y *Big) *Big {
 
 		shift := zp + yp - x.Precision()
 		z.exp = (x.exp - y.exp) - shift
-		expadj := ideal - z.exp
+		if z.exp < c.MinScale() {
+			shift -= c.quoTiny(z)
+		}
 		if shift > 0 {
 			if sx, ok := checked.MulPow10(x.compact, uint64(shift)); ok {
-				if z.quo(m, sx, x.form, y.compact, y.form) && expadj > 0 {
-					c.simpleReduce(z)
-				}
-				return z
+				return c.quoFix(z, z.quo(m, c.source(), sx, x.form, y.compact, y.form), ideal)
 			}
 			xb := z.unscaled.SetUint64(x.compact)
 			xb = checked.MulBigPow10(xb, xb, uint64(shift))
 			yb := new(big.Int).SetUint64(y.compact)
-			if z.quoBig(m, xb, x.form, yb, y.form, yb) && expadj > 0 {
-				c.simpleReduce(z)
-			}
-			return z
+			return c.quoFix(z, z.quoBig(m, c.source(), xb, x.form, yb, y.form, new(big.Int)), ideal)
 		}
 		if shift < 0 {
 			if sy, ok := checked.MulPow10(y.compact, uint64(-shift)); ok {
-				if z.quo(m, x.compact, x.form, sy, y.form) && expadj > 0 {
-					c.simpleReduce(z)
-				}
-				return z
+				return c.quoFix(z, z.quo(m, c.source(), x.compact, x.form, sy, y.form), ideal)
 			}
-			yb := z.unscaled.SetUint64(y.compact)
+			yb := new(big.Int).SetUint64(y.compact)
 			yb = checked.MulBigPow10(yb, yb, uint64(-shift))
 			xb := new(big.Int).SetUint64(x.compact)
-			if z.quoBig(m, xb, x.form, yb, y.form, xb) && expadj > 0 {
-				c.simpleReduce(z)
-			}
-			return z
-		}
-		if z.quo(m, x.compact, x.form, y.compact, y.form) && expadj > 0 {
-			c.simpleReduce(z)
+			return c.quoFix(z, z.quoBig(m, c.source(), xb, x.form, yb, y.form, xb), ideal)
 		}
-		return z
+		return c.quoFix(z, z.quo(m, c.source(), x.compact, x.form, y.compact, y.form), ideal)
 	}
 
 	xb, yb := &x.unscaled, &y.unscaled
@@ -487,29 +567,71 @@ func (c Context) Quo(z, x, y *Big) *Big {
 
 	shift := zp + yp - x.Precision()
 	z.exp = (x.exp - y.exp) - shift
+	if z.exp < c.MinScale() {
+		shift -= c.quoTiny(z)
+	}
 
 	var tmp *big.Int
 	if shift > 0 {
 		tmp = alias(&z.unscaled, yb)
 		xb = checked.MulBigPow10(tmp, xb, uint64(shift))
 	} else if shift < 0 {
-		tmp = alias(&z.unscaled, xb)
-		yb = checked.MulBigPow10(tmp, yb, uint64(-shift))
+		// quoBig rounds using y after storing the quotient in z, so y can't
+		// share z's storage.
+		yb = checked.MulBigPow10(new(big.Int), yb, uint64(-shift))
+		tmp = new(big.Int)
 	} else {
 		tmp = new(big.Int)
 	}
 
-	expadj := ideal - z.exp
-	if z.quoBig(m, xb, x.form, yb, y.form, alias(tmp, &z.unscaled)) && expadj > 0 {
-		c.simpleReduce(z)
+	return c.quoFix(z, z.quoBig(m, c.source(), xb, x.form, yb, y.form, alias(tmp, &z.unscaled)), ideal)
+}
+
+// quoTiny raises z's exponent, the exponent of a quotient that has all of c's
+// digits, to etiny if it's smaller and returns how much it was raised. That
+// way, a subnormal quotient is rounded once, to etiny, instead of to c's
+// precision and then again by fix.
+func (c *Context) quoTiny(z *Big) int {
+	if precision(*c) == UnlimitedPrecision {
+		return 0
 	}
-	return z
+	tiny := c.etiny()
+	if z.exp >= tiny {
+		return 0
+	}
+	d := tiny - z.exp
+	z.exp = tiny
+	return d
 }
 
-func (z *Big) quo(m RoundingMode, x uint64, xneg form, y uint64, yneg form) bool {
+// quoFix reduces z, the result of a division, to the ideal exponent if it's
+// exact, and then fixes it.
+func (c *Context) quoFix(z *Big, exact bool, ideal int) *Big {
+	if exact && z.exp < ideal {
+		quoReduce(z, ideal)
+	}
+	if adj := z.adjusted(); adj < c.MinScale() {
+		if !exact && z.IsFinite() {
+			// quoTiny rounded z to etiny, so fix won't.
+			z.Context.Conditions |= Underflow | Subnormal
+			if z.compact == 0 {
+				z.Context.Conditions |= Clamped
+			}
+		}
+	} else if adj <= c.MaxScale() && !c.Clamp {
+		return z // fix has nothing to do.
+	}
+	return c.fix(z)
+}
+
+func (z *Big) quo(
+	m RoundingMode, src rand.Source64,
+	x uint64, xneg form,
+	y uint64, yneg form,
+) bool {
 	z.form = xneg ^ yneg
 	z.compact = x / y
-	z.precision = arith.Length(z.compact)
+	z.precision = int32(arith.Length(z.compact))
 
 	r := x % y
 	if r == 0 {
@@ -526,12 +648,22 @@ func (z *Big) quo(m RoundingMode, x uint64, xneg form, y uint64, yneg form) bool
 		rc = arith.Cmp(r2, y)
 	}
 
-	if m == unnecessary {
+	switch m {
+	case unnecessary:
 		z.setNaN(InvalidOperation|InvalidContext|InsufficientStorage, qnan, quotermexp)
 		return false
+	case Unnecessary:
+		z.setNaN(InvalidOperation, qnan, roundunnec)
+		return false
 	}
 
-	if m.needsInc(z.compact&1 != 0, rc, xneg == yneg) {
+	var inc bool
+	if m == Stochastic {
+		inc = stochastic(src, r, y)
+	} else {
+		inc = m.needsInc(z.compact%10, rc, xneg == yneg)
+	}
+	if inc {
 		z.Context.Conditions |= Rounded
 		z.compact++
 
@@ -547,7 +679,7 @@ func (z *Big) quo(m RoundingMode, x uint64, xneg form, y uint64, yneg form) bool
 		// which is rounded up to
 		//
 		//   1.00000000000000000 (precision = 18)
-		if arith.Length(z.compact) != z.precision {
+		if arith.Length(z.compact) != int(z.precision) {
 			z.compact /= 10
 			z.exp++
 		}
@@ -556,7 +688,7 @@ func (z *Big) quo(m RoundingMode, x uint64, xneg form, y uint64, yneg form) bool
 }
 
 func (z *Big) quoBig(
-	m RoundingMode,
+	m RoundingMode, src rand.Source64,
 	x *big.Int, xneg form,
 	y *big.Int, yneg form,
 	r *big.Int,
@@ -576,6 +708,11 @@ func (z *Big) quoBig(
 		return false
 	}
 
+	var inc bool
+	if m == Stochastic {
+		inc = stochasticBig(src, r, y)
+	}
+
 	var rc int
 	rv := r.Uint64()
 	// Drop into integers if possible.
@@ -585,16 +722,23 @@ func (z *Big) quoBig(
 		rc = r.Mul(r, cst.TwoInt).CmpAbs(y)
 	}
 
-	if m == unnecessary {
+	switch m {
+	case unnecessary:
 		z.setNaN(InvalidOperation|InvalidContext|InsufficientStorage, qnan, quotermexp)
 		return false
+	case Unnecessary:
+		z.setNaN(InvalidOperation, qnan, roundunnec)
+		return false
 	}
 
-	if m.needsInc(q.Bit(0) != 0, rc, xneg == yneg) {
+	if m != Stochastic {
+		inc = m.needsInc(lsd(q), rc, xneg == yneg)
+	}
+	if inc {
 		z.Context.Conditions |= Rounded
-		z.precision = arith.BigLength(q)
+		z.precision = int32(arith.BigLength(q))
 		arith.Add(q, q, 1)
-		if arith.BigLength(q) != z.precision {
+		if arith.BigLength(q) != int(z.precision) {
 			q.Quo(q, cst.TenInt)
 			z.exp++
 		}
@@ -603,9 +747,29 @@ func (z *Big) quoBig(
 	return false
 }
 
+// lsd returns the least significant decimal digit of x, which must not be
+// negative.
+func lsd(x *big.Int) uint64 {
+	// Each word above the first is a multiple of 2**32 or 2**64, both of which
+	// are 6 mod 10, so x mod 10 == (w[0] + 6*(w[1] + w[2] + ...)) mod 10.
+	w := x.Bits()
+	if len(w) == 0 {
+		return 0
+	}
+	var d uint64
+	for _, v := range w[1:] {
+		d += uint64(v) % 10
+	}
+	return (uint64(w[0])%10 + 6*d) % 10
+}
+
 // QuoInt sets z to x / y with the remainder truncated. See QuoRem for more
 // details.
 func (c Context) QuoInt(z, x, y *Big) *Big {
+	if c.hook() != nil {
+		c.watched("QuoInt", func(c Context) { c.QuoInt(z, x, y) }, z, x, y)
+		return z
+	}
 	if debug {
 		x.validate()
 		y.validate()
@@ -659,6 +823,10 @@ func (c Context) QuoInt(z, x, y *Big) *Big {
 // QuoRem sets z to the quotient x / y and r to the remainder x % y, such that
 // x = z * y + r, and returns the pair (z, r).
 func (c Context) QuoRem(z, x, y, r *Big) (*Big, *Big) {
+	if c.hook() != nil {
+		c.watched("QuoRem", func(c Context) { c.QuoRem(z, x, y, r) }, z, x, y)
+		return z, r
+	}
 	if debug {
 		x.validate()
 		y.validate()
@@ -812,6 +980,10 @@ func (m RoundingMode) quoremBig(
 
 // Reduce reduces a finite z to its most simplest form.
 func (c Context) Reduce(z *Big) *Big {
+	if c.hook() != nil {
+		c.watched("Reduce", func(c Context) { c.Reduce(z) }, z, z)
+		return z
+	}
 	if debug {
 		z.validate()
 	}
@@ -819,6 +991,72 @@ func (c Context) Reduce(z *Big) *Big {
 	return c.simpleReduce(z)
 }
 
+// quoReduce removes trailing zeros from z, the exact result of a division,
+// until its exponent reaches ideal. It's simpleReduce, but limited to removing
+// ideal - z.exp zeros.
+func quoReduce(z *Big, ideal int) *Big {
+	n := ideal - z.exp
+
+	if z.compact == cst.Inflated {
+		if z.unscaled.Bit(0) != 0 {
+			return z
+		}
+
+		var r big.Int
+		for n >= 6 && z.precision >= 20 {
+			z.unscaled.QuoRem(&z.unscaled, cst.OneMillionInt, &r)
+			if r.Sign() != 0 {
+				z.unscaled.Mul(&z.unscaled, cst.OneMillionInt)
+				z.unscaled.Add(&z.unscaled, &r)
+				break
+			}
+			z.exp += 6
+			z.precision -= 6
+			n -= 6
+
+			// Try to avoid reconstruction for odd numbers.
+			if z.unscaled.Bit(0) != 0 {
+				return z.norm()
+			}
+		}
+
+		for n > 0 && z.precision >= 20 {
+			z.unscaled.QuoRem(&z.unscaled, cst.TenInt, &r)
+			if r.Sign() != 0 {
+				z.unscaled.Mul(&z.unscaled, cst.TenInt)
+				z.unscaled.Add(&z.unscaled, &r)
+				break
+			}
+			z.exp++
+			z.precision--
+			n--
+			if z.unscaled.Bit(0) != 0 {
+				break
+			}
+		}
+
+		if z.precision >= 20 {
+			return z.norm()
+		}
+		z.compact = z.unscaled.Uint64()
+	}
+
+	if z.compact == 0 {
+		return z
+	}
+	for ; n >= 4 && z.compact%10000 == 0; n -= 4 {
+		z.compact /= 10000
+		z.exp += 4
+		z.precision -= 4
+	}
+	for ; n > 0 && z.compact%10 == 0; n-- {
+		z.compact /= 10
+		z.exp++
+		z.precision--
+	}
+	return z
+}
+
 // simpleReduce is the same as Reduce, but it does not round prior to reducing
 // the decimal.
 func (c Context) simpleReduce(z *Big) *Big {
@@ -891,6 +1129,10 @@ func (c Context) simpleReduce(z *Big) *Big {
 
 // Rem sets z to the remainder x % y. See QuoRem for more details.
 func (c Context) Rem(z, x, y *Big) *Big {
+	if c.hook() != nil {
+		c.watched("Rem", func(c Context) { c.Rem(z, x, y) }, z, x, y)
+		return z
+	}
 	if debug {
 		x.validate()
 		y.validate()
@@ -942,10 +1184,105 @@ func (c Context) Rem(z, x, y *Big) *Big {
 	return z.Set(x)
 }
 
+// RemNear sets z to the remainder x - y×n, where n is the integer nearest to
+// x / y, and returns z. If two integers are equally near, n is the even one.
+// Unlike Rem, the sign of z need not match the sign of x, and |z| ≤ |y| / 2.
+//
+// If n has more digits than c's precision, z is set to NaN and
+// DivisionImpossible is raised.
+func (c Context) RemNear(z, x, y *Big) *Big {
+	if c.hook() != nil {
+		c.watched("RemNear", func(c Context) { c.RemNear(z, x, y) }, z, x, y)
+		return z
+	}
+	if debug {
+		x.validate()
+		y.validate()
+	}
+	if z.invalidContext(c) {
+		return z
+	}
+
+	if x.IsFinite() && y.IsFinite() {
+		if y.compact == 0 {
+			if x.compact == 0 {
+				// 0 / 0
+				return z.setNaN(InvalidOperation|DivisionUndefined, qnan, quo00)
+			}
+			// x / 0
+			return z.setNaN(InvalidOperation|DivisionByZero, qnan, remx0)
+		}
+		if x.compact == 0 {
+			// 0 / y
+			return z.setZero(x.form&signbit, min(x.exp, y.exp))
+		}
+
+		// quorem clobbers z, but we still need x's exponent and |y|
+		// afterward.
+		var ay Big
+		ay.copyAbs(y)
+		xexp := x.exp
+
+		var q Big
+		_, z = c.quorem(&q, z, x, y)
+		if z.IsNaN(0) {
+			return z
+		}
+		z.exp = min(xexp, ay.exp)
+		q.exp = 0
+
+		// z has the same sign as x and |z| < |y|. If |z| > |y| / 2 (or it's
+		// a tie and q is odd) the next integer away from zero is nearer, so
+		// replace z with -sign(x) × (|y| - |z|).
+		uctx := Context{Precision: UnlimitedPrecision}
+		var r2 Big
+		uctx.Add(&r2, z, z)
+		odd := q.isCompact() && q.compact&1 != 0 ||
+			!q.isCompact() && q.unscaled.Bit(0) != 0
+		if r := r2.CmpAbs(&ay); r > 0 || r == 0 && odd {
+			neg := z.form&signbit == 0
+			var az Big
+			az.copyAbs(z)
+			uctx.Sub(z, &ay, &az)
+			if neg {
+				z.form |= signbit
+			}
+			var one Big
+			uctx.Add(&q, &q, one.SetMantScale(1, 0))
+		}
+		if q.Precision() > precision(c) {
+			return z.setNaN(DivisionImpossible, qnan, quointprec)
+		}
+		return c.round(z)
+	}
+
+	// NaN / NaN
+	// NaN / y
+	// x / NaN
+	if z.checkNaNs(x, y, division) {
+		return z
+	}
+
+	if x.form&inf != 0 {
+		if y.form&inf != 0 {
+			// ±Inf / ±Inf
+			return z.setNaN(InvalidOperation, qnan, quoinfinf)
+		}
+		// ±Inf / y
+		return z.setNaN(InvalidOperation, qnan, reminfy)
+	}
+	// x / ±Inf
+	return z.Set(x)
+}
+
 // Round rounds z down to the Context's precision and returns z. The result is
 // undefined if z is not finite. The result of Round will always be within the
 // interval [⌊10**x⌋, z] where x = the precision of z.
 func (c Context) Round(z *Big) *Big {
+	if c.hook() != nil {
+		c.watched("Round", func(c Context) { c.Round(z) }, z, z)
+		return z
+	}
 	if debug {
 		z.validate()
 	}
@@ -953,6 +1290,10 @@ func (c Context) Round(z *Big) *Big {
 		return z
 	}
 
+	if scale, ok := c.fixedScale(); ok {
+		return c.roundScale(z, scale)
+	}
+
 	n := precision(c)
 	if n == UnlimitedPrecision || z.isSpecial() {
 		return z
@@ -964,8 +1305,8 @@ func (c Context) Round(z *Big) *Big {
 	}
 
 	shift := zp - n
-	if shift > c.maxScale() {
-		return z.xflow(c.minScale(), false, true)
+	if shift > c.MaxScale() {
+		return z.xflow(c.MinScale(), false, true)
 	}
 	z.exp += shift
 
@@ -975,38 +1316,244 @@ func (c Context) Round(z *Big) *Big {
 	return c.fix(z)
 }
 
-func (c Context) shiftr(z *Big, n uint64) bool {
-	if zp := uint64(z.Precision()); n >= zp {
-		z.compact = 0
-		z.precision = 1
-		return n == zp
+// RoundIncrement rounds z to an integral multiple of inc using c's
+// RoundingMode and returns z. inc must be finite and greater than zero. For
+// example, Swiss cash rounding is
+//
+//   ctx.RoundIncrement(z, decimal.New(5, 2))
+//
+// The result has the same scale as inc and, unlike Round, is not rounded to
+// c's precision. Instead, if the result would have more digits than c's
+// precision z is set to NaN and InvalidOperation is raised, like Quantize.
+// Infinities are not changed.
+func (c Context) RoundIncrement(z, inc *Big) *Big {
+	if c.hook() != nil {
+		c.watched("RoundIncrement", func(c Context) { c.RoundIncrement(z, inc) }, z, z, inc)
+		return z
+	}
+	if debug {
+		z.validate()
+		inc.validate()
+	}
+	if z.invalidContext(c) {
+		return z
+	}
+
+	if z.isSpecial() || inc.isSpecial() {
+		if z.checkNaNs(z, inc, rounding) {
+			return z
+		}
+		if inc.form&inf != 0 {
+			return z.setNaN(InvalidOperation, qnan, roundincinv)
+		}
+		return z
+	}
+	if inc.compact == 0 || inc.Signbit() {
+		return z.setNaN(InvalidOperation, qnan, roundincinv)
 	}
 
 	if z.compact == 0 {
-		return false
+		z.exp = inc.exp
+		return z
+	}
+
+	prec := precision(c)
+	if prec != UnlimitedPrecision && z.adjusted()-inc.exp >= prec {
+		return z.setNaN(InvalidOperation, qnan, roundincprec)
+	}
+	if z.exp < inc.exp {
+		z.Context.Conditions |= Rounded
+	}
+
+	var xb, yb big.Int
+	if z.isCompact() {
+		xb.SetUint64(z.compact)
+	} else {
+		xb.Set(&z.unscaled)
+	}
+	if inc.isCompact() {
+		yb.SetUint64(inc.compact)
+	} else {
+		yb.Set(&inc.unscaled)
+	}
+
+	// If |z| is much smaller than inc the quotient only needs to be non-zero
+	// and, for the Stochastic mode, too small to matter, so use a smaller
+	// stand-in for z instead of scaling inc by a large power of ten.
+	xexp := z.exp
+	if z.adjusted() < inc.exp-20 {
+		xb.SetUint64(1)
+		xexp = inc.exp - 21
+	}
+	if e := xexp - inc.exp; e > 0 {
+		checked.MulBigPow10(&xb, &xb, uint64(e))
+	} else if e < 0 {
+		checked.MulBigPow10(&yb, &yb, uint64(-e))
+	}
+
+	var q Big
+	if xb.IsUint64() && yb.IsUint64() {
+		q.quo(c.RoundingMode, c.source(), xb.Uint64(), z.form, yb.Uint64(), 0)
+	} else {
+		q.quoBig(c.RoundingMode, c.source(), &xb, z.form, &yb, 0, new(big.Int))
+	}
+	z.Context.Conditions |= q.Context.Conditions
+	if q.IsNaN(0) {
+		return z.setNaN(InvalidOperation, qnan, roundunnec)
+	}
+
+	// The quotient might have been rounded up to a power of ten, in which case
+	// its exponent is 1 instead of 0.
+	uctx := Context{Precision: UnlimitedPrecision}
+	uctx.Mul(z, &q, inc)
+	if z.exp > inc.exp {
+		z.shiftl(uint64(z.exp - inc.exp))
+	}
+	if prec != UnlimitedPrecision && z.Precision() > prec {
+		return z.setNaN(InvalidOperation, qnan, roundincprec)
+	}
+	return z
+}
+
+// RoundScale rounds z to n digits after the decimal point using c's
+// RoundingMode and returns z. Unlike Quantize, z is never padded with zeros, so
+// it's not changed if it already has n or fewer digits after the decimal
+// point. And, like Quantize, the result is not rounded to c's precision.
+// Infinities are not changed.
+func (c Context) RoundScale(z *Big, n int) *Big {
+	if c.hook() != nil {
+		c.watched("RoundScale", func(c Context) { c.RoundScale(z, n) }, z, z, New(1, n))
+		return z
+	}
+	if debug {
+		z.validate()
+	}
+	if z.invalidContext(c) {
+		return z
+	}
+
+	if z.isSpecial() {
+		z.checkNaNs(z, z, rounding)
+		return z
+	}
+	if z.exp >= -n {
+		return z
+	}
+	if z.compact == 0 {
+		z.exp = -n
+		return z
+	}
+	c.Precision = UnlimitedPrecision
+	return c.roundScale(z, n)
+}
+
+// shiftr rounds off the n least significant digits of z using c's
+// RoundingMode. It returns true if no non-zero digits were discarded.
+func (c Context) shiftr(z *Big, n uint64) bool {
+	if z.compact == 0 {
+		return true
+	}
+
+	if zp := uint64(z.Precision()); n > zp {
+		// Every digit is shifted out. Replace the coefficient with a single
+		// sticky digit below the rounding digit so the discarded digits still
+		// round correctly.
+		z.compact = 1
+		z.precision = 1
+		n = 2
 	}
 
 	m := c.RoundingMode
 	if z.isCompact() {
 		if y, ok := arith.Pow10(n); ok {
-			return z.quo(m, z.compact, z.form, y, 0)
+			return z.quo(m, c.source(), z.compact, z.form, y, 0)
 		}
 		z.unscaled.SetUint64(z.compact)
 		z.compact = cst.Inflated
 	}
 	var r big.Int
-	return z.quoBig(m, &z.unscaled, z.form, arith.BigPow10(n), 0, &r)
+	return z.quoBig(m, c.source(), &z.unscaled, z.form, arith.BigPow10(n), 0, &r)
 }
 
 func (c Context) round(z *Big) *Big {
-	if c.OperatingMode == GDA {
+	if _, ok := c.fixedScale(); ok || c.OperatingMode == GDA {
 		return c.Round(z)
 	}
 	return c.fix(z)
 }
 
+// roundScale rounds z to exactly scale digits after the decimal point using
+// c's RoundingMode and returns z.
+func (c Context) roundScale(z *Big, scale int) *Big {
+	if z.isSpecial() {
+		return z
+	}
+
+	n := -scale
+	if z.exp < n {
+		shift := n - z.exp
+		z.exp = n
+		z.Context.Conditions |= Rounded
+		c.shiftr(z, uint64(shift))
+	}
+	p := precision(c)
+	if z.exp > n {
+		// Check the number of digits before padding the coefficient, which
+		// could otherwise take an unbounded amount of memory.
+		if p != UnlimitedPrecision && z.compact != 0 && z.adjusted()+scale >= p {
+			return c.overflow(z, z.Signbit())
+		}
+		z.shiftl(uint64(z.exp - n))
+	}
+
+	if p != UnlimitedPrecision && z.compact != 0 && z.Precision() > p {
+		return c.overflow(z, z.Signbit())
+	}
+	return z
+}
+
+// quoScale sets z to x / y rounded to scale digits after the decimal point
+// and returns z. Both x and y must be finite and y must not be zero.
+func (c Context) quoScale(z, x, y *Big, scale int) *Big {
+	xb := &x.unscaled
+	if x.isCompact() {
+		xb = new(big.Int).SetUint64(x.compact)
+	}
+	yb := &y.unscaled
+	if y.isCompact() {
+		yb = new(big.Int).SetUint64(y.compact)
+	}
+
+	// |x / y| is in [10^(d-1), 10^(d+1)), so the quotient has about d + scale
+	// digits.
+	d := x.adjusted() - y.adjusted()
+	if p := precision(c); p != UnlimitedPrecision && d-1+scale >= p {
+		return c.overflow(z, x.Signbit() != y.Signbit())
+	}
+
+	// Scale the dividend or divisor so the quotient's exponent is -scale.
+	if shift := x.exp - y.exp + scale; shift > 0 {
+		xb = checked.MulBigPow10(new(big.Int), xb, uint64(shift))
+	} else if d+scale < -1 {
+		// |x / y| is less than a tenth of the last place, so it rounds like
+		// any other number that small. Like shiftr, use a sticky digit below
+		// the rounding digit instead of scaling y by an unbounded amount.
+		xb, yb = new(big.Int).SetUint64(1), new(big.Int).SetUint64(100)
+	} else if shift < 0 {
+		yb = checked.MulBigPow10(new(big.Int), yb, uint64(-shift))
+	}
+
+	z.exp = -scale
+	z.quoBig(c.RoundingMode, c.source(), xb, x.form, yb, y.form, new(big.Int))
+	return c.roundScale(z, scale)
+}
+
 // RoundToInt rounds z down to an integral value.
 func (c Context) RoundToInt(z *Big) *Big {
+	if c.hook() != nil {
+		c.watched("RoundToInt", func(c Context) { c.RoundToInt(z) }, z, z)
+		return z
+	}
 	if z.isSpecial() || z.exp >= 0 {
 		return z
 	}
@@ -1014,14 +1561,56 @@ func (c Context) RoundToInt(z *Big) *Big {
 	return c.Quantize(z, 0)
 }
 
+// RoundToIntegralValue is like RoundToInt, but it never raises Inexact or
+// Rounded.
+func (c Context) RoundToIntegralValue(z *Big) *Big {
+	if c.hook() != nil {
+		c.watched("RoundToIntegralValue", func(c Context) { c.RoundToIntegralValue(z) }, z, z)
+		return z
+	}
+	if debug {
+		z.validate()
+	}
+	if z.invalidContext(c) || z.isSpecial() || z.exp >= 0 {
+		return z
+	}
+
+	// Round a copy of z so that the Inexact and Rounded conditions it raises
+	// are discarded along with it.
+	var t Big
+	t.Copy(z)
+	shift := uint64(-t.exp)
+	t.exp = 0
+	c.shiftr(&t, shift)
+	if t.IsNaN(0) {
+		// Only the Unnecessary RoundingMode fails.
+		return z.setNaN(InvalidOperation, qnan, roundunnec)
+	}
+	return z.Copy(&t)
+}
+
 // Set sets z to x and returns z. The result might be rounded, even if z == x.
 func (c Context) Set(z, x *Big) *Big {
+	if c.hook() != nil {
+		c.watched("Set", func(c Context) { c.Set(z, x) }, z, x)
+		return z
+	}
 	return c.Round(z.Copy(x))
 }
 
 // SetString sets z to the value of s, returning z and a bool indicating success.
 // See Big.SetString for valid formats.
 func (c Context) SetString(z *Big, s string) (*Big, bool) {
+	if c.hook() != nil {
+		// Report the exact value of s as the operand.
+		x, _ := WithContext(c).SetString(s)
+		var ok bool
+		c.watched("SetString", func(c Context) { _, ok = c.SetString(z, s) }, z, x)
+		if !ok {
+			return nil, false
+		}
+		return z, true
+	}
 	if _, ok := z.SetString(s); !ok {
 		return nil, false
 	}
@@ -1030,6 +1619,10 @@ func (c Context) SetString(z *Big, s string) (*Big, bool) {
 
 // Sub sets z to x - y and returns z.
 func (c Context) Sub(z, x, y *Big) *Big {
+	if c.hook() != nil {
+		c.watched("Sub", func(c Context) { c.Sub(z, x, y) }, z, x, y)
+		return z
+	}
 	if debug {
 		x.validate()
 		y.validate()
@@ -1039,7 +1632,11 @@ func (c Context) Sub(z, x, y *Big) *Big {
 	}
 
 	if x.IsFinite() && y.IsFinite() {
-		z.form = finite | c.add(z, x, x.form, y, y.form^signbit)
+		sign, ok := c.add(z, x, x.form, y, y.form^signbit)
+		if !ok {
+			return z
+		}
+		z.form = finite | sign
 		return c.round(z)
 	}
 
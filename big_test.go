@@ -3,11 +3,13 @@ package decimal_test
 import (
 	"math"
 	"math/big"
+	"math/bits"
 	"math/rand"
 	"strconv"
 	"strings"
 	"sync"
 	"testing"
+	"unsafe"
 
 	"github.com/ericlagergren/decimal"
 	"github.com/ericlagergren/decimal/internal/test"
@@ -17,6 +19,7 @@ func TestBig_Abs(t *testing.T)        { test.Abs.Test(t) }
 func TestBig_Add(t *testing.T)        { test.Add.Test(t) }
 func TestBig_Class(t *testing.T)      { test.Class.Test(t) }
 func TestBig_Cmp(t *testing.T)        { test.Cmp.Test(t) }
+func TestBig_CmpSignal(t *testing.T)  { test.CmpSignal.Test(t) }
 func TestBig_FMA(t *testing.T)        { test.FMA.Test(t) }
 func TestBig_Mul(t *testing.T)        { test.Mul.Test(t) }
 func TestBig_Neg(t *testing.T)        { test.Neg.Test(t) }
@@ -33,6 +36,8 @@ func TestBig_SignBit(t *testing.T)    { test.Signbit.Test(t) }
 func TestBig_String(t *testing.T)     { test.CTS.Test(t) }
 func TestBig_Sub(t *testing.T)        { test.Sub.Test(t) }
 
+func TestBig_RoundToIntegralValue(t *testing.T) { test.RoundToIV.Test(t) }
+
 var rnd = rand.New(rand.NewSource(0))
 
 func rndn(min, max int) int {
@@ -216,6 +221,191 @@ func TestBig_Prec(t *testing.T) {
 	// confirmed to work inside internal/arith/intlen_test.go
 }
 
+func TestBig_RoundDigits(t *testing.T) {
+	for i, test := range [...]struct {
+		x string
+		n int
+		m decimal.RoundingMode
+		r string
+		c decimal.Condition
+	}{
+		0: {"1.2345", 3, decimal.ToNearestEven, "1.23", decimal.Inexact | decimal.Rounded},
+		1: {"1.2355", 4, decimal.ToNearestEven, "1.236", decimal.Inexact | decimal.Rounded},
+		2: {"1.2355", 4, decimal.ToZero, "1.235", decimal.Inexact | decimal.Rounded},
+		3: {"-1.2301", 3, decimal.ToNegativeInf, "-1.24", decimal.Inexact | decimal.Rounded},
+		4: {"1.2300", 3, decimal.AwayFromZero, "1.23", decimal.Rounded},
+		5: {"99.5", 2, decimal.ToNearestAway, "1.0E+2", decimal.Inexact | decimal.Rounded},
+		6: {"1.2345", 0, decimal.ToZero, "1.2345", 0},
+		7: {"123456789012345678901234567890.5", 30, decimal.ToNearestTowardZero, "123456789012345678901234567890", decimal.Inexact | decimal.Rounded},
+	} {
+		z, _ := decimal.WithContext(decimal.Context{OperatingMode: decimal.GDA}).SetString(test.x)
+		z.RoundDigits(test.n, test.m)
+		if s := z.String(); s != test.r {
+			t.Fatalf("#%d: wanted %q, got %q", i, test.r, s)
+		}
+		if c := z.Context.Conditions; c != test.c {
+			t.Fatalf("#%d: wanted %q, got %q", i, test.c, c)
+		}
+		if z.Context.RoundingMode != decimal.ToNearestEven || z.Context.Precision != 0 {
+			t.Fatalf("#%d: Context was modified: %+v", i, z.Context)
+		}
+	}
+}
+
+func TestBig_RoundIncrement(t *testing.T) {
+	const big = "123456789012345678901234567890"
+	for i, test := range [...]struct {
+		x, inc string
+		m      decimal.RoundingMode
+		r      string
+		c      decimal.Condition
+	}{
+		0:  {"1.02", "0.05", decimal.ToNearestEven, "1.00", decimal.Inexact | decimal.Rounded},
+		1:  {"1.03", "0.05", decimal.ToNearestEven, "1.05", decimal.Inexact | decimal.Rounded},
+		2:  {"1.025", "0.05", decimal.ToNearestEven, "1.00", decimal.Inexact | decimal.Rounded},
+		3:  {"1.075", "0.05", decimal.ToNearestEven, "1.10", decimal.Inexact | decimal.Rounded},
+		4:  {"1.075", "0.05", decimal.ToNearestAway, "1.10", decimal.Inexact | decimal.Rounded},
+		5:  {"-1.074", "0.05", decimal.ToZero, "-1.05", decimal.Inexact | decimal.Rounded},
+		6:  {"-1.074", "0.05", decimal.ToNegativeInf, "-1.10", decimal.Inexact | decimal.Rounded},
+		7:  {"1.1", "0.05", decimal.ToNearestEven, "1.10", 0},
+		8:  {"1.100", "0.05", decimal.ToNearestEven, "1.10", decimal.Rounded},
+		9:  {"101.37", "0.25", decimal.ToNearestEven, "101.25", decimal.Inexact | decimal.Rounded},
+		10: {"101.38", "0.25", decimal.ToNearestEven, "101.50", decimal.Inexact | decimal.Rounded},
+		11: {"1234", "50", decimal.ToNearestEven, "1250", decimal.Inexact | decimal.Rounded},
+		12: {"1275", "50", decimal.ToNearestEven, "1300", decimal.Inexact | decimal.Rounded},
+		13: {"1234", "5E+1", decimal.ToNearestEven, "1.25E+3", decimal.Inexact | decimal.Rounded},
+		14: {"9.99", "0.1", decimal.ToNearestEven, "10.0", decimal.Inexact | decimal.Rounded},
+		15: {"0", "0.05", decimal.ToNearestEven, "0.00", 0},
+		16: {"1E-100", "0.05", decimal.AwayFromZero, "0.05", decimal.Inexact | decimal.Rounded},
+		17: {"1E-100", "0.05", decimal.ToNearestEven, "0.00", decimal.Inexact | decimal.Rounded},
+		18: {big + ".01", "0.25", decimal.ToNearestEven, big + ".00", decimal.Inexact | decimal.Rounded},
+		19: {big + ".2", big + "0", decimal.ToNearestEven, "0", decimal.Inexact | decimal.Rounded},
+		20: {"7", big, decimal.AwayFromZero, big, decimal.Inexact | decimal.Rounded},
+		21: {"Inf", "0.05", decimal.ToNearestEven, "Infinity", 0},
+		22: {"1", "Inf", decimal.ToNearestEven, "NaN", decimal.InvalidOperation},
+		23: {"1", "0", decimal.ToNearestEven, "NaN", decimal.InvalidOperation},
+		24: {"1", "-0.05", decimal.ToNearestEven, "NaN", decimal.InvalidOperation},
+		25: {"NaN", "0.05", decimal.ToNearestEven, "NaN", 0},
+		26: {"1E+100", "0.05", decimal.ToNearestEven, "NaN", decimal.InvalidOperation},
+	} {
+		ctx := decimal.Context{Precision: 40, OperatingMode: decimal.GDA}
+		x, _ := decimal.WithContext(ctx).SetString(test.x)
+		inc, _ := new(decimal.Big).SetString(test.inc)
+		z := decimal.WithContext(ctx).Copy(x)
+		z.RoundIncrement(inc, test.m)
+		if test.r == "NaN" {
+			// Ignore the payload.
+			if !z.IsNaN(0) {
+				t.Fatalf("#%d: round(%s, %s): wanted NaN, got %s", i, x, inc, z)
+			}
+		} else if s := z.String(); s != test.r {
+			t.Fatalf("#%d: round(%s, %s): wanted %q, got %q", i, x, inc, test.r, s)
+		}
+		if c := z.Context.Conditions; c != test.c {
+			t.Fatalf("#%d: round(%s, %s): wanted %q, got %q", i, x, inc, test.c, c)
+		}
+
+		// z aliases inc.
+		if test.r != "NaN" {
+			z.Copy(inc)
+			x.RoundIncrement(z, test.m)
+			if s := x.String(); s != test.r {
+				t.Fatalf("#%d: round(%s, %s) (aliased): wanted %q, got %q", i, x, inc, test.r, s)
+			}
+		}
+	}
+}
+
+func TestBig_RoundScale(t *testing.T) {
+	for i, test := range [...]struct {
+		x string
+		n int
+		m decimal.RoundingMode
+		r string
+		c decimal.Condition
+	}{
+		0:  {"1.2345", 2, decimal.ToNearestEven, "1.23", decimal.Inexact | decimal.Rounded},
+		1:  {"1.235", 2, decimal.ToNearestEven, "1.24", decimal.Inexact | decimal.Rounded},
+		2:  {"1.235", 2, decimal.ToNearestTowardZero, "1.23", decimal.Inexact | decimal.Rounded},
+		3:  {"-1.231", 2, decimal.ToNegativeInf, "-1.24", decimal.Inexact | decimal.Rounded},
+		4:  {"1.2", 2, decimal.ToNearestEven, "1.2", 0},
+		5:  {"1.200", 2, decimal.ToNearestEven, "1.20", decimal.Rounded},
+		6:  {"9.996", 2, decimal.ToNearestEven, "10.00", decimal.Inexact | decimal.Rounded},
+		7:  {"1234.5", -2, decimal.ToNearestEven, "1.2E+3", decimal.Inexact | decimal.Rounded},
+		8:  {"0.000", 1, decimal.ToNearestEven, "0.0", 0},
+		9:  {"0.004", 2, decimal.AwayFromZero, "0.01", decimal.Inexact | decimal.Rounded},
+		10: {"1234567890123456789012345678.901", 2, decimal.ToPositiveInf, "1234567890123456789012345678.91", decimal.Inexact | decimal.Rounded},
+		11: {"-Inf", 2, decimal.ToNearestEven, "-Infinity", 0},
+	} {
+		z, _ := decimal.WithContext(decimal.Context{Precision: 5, OperatingMode: decimal.GDA}).SetString(test.x)
+		z.RoundScale(test.n, test.m)
+		if s := z.String(); s != test.r {
+			t.Fatalf("#%d: wanted %q, got %q", i, test.r, s)
+		}
+		if c := z.Context.Conditions; c != test.c {
+			t.Fatalf("#%d: wanted %q, got %q", i, test.c, c)
+		}
+	}
+}
+
+func TestBig_RemNear(t *testing.T) {
+	for i, test := range [...]struct {
+		x, y string
+		r    string
+		c    decimal.Condition
+	}{
+		// From the General Decimal Arithmetic specification.
+		0:  {"2.1", "3", "-0.9", 0},
+		1:  {"10", "6", "-2", 0},
+		2:  {"10", "3", "1", 0},
+		3:  {"-10", "3", "-1", 0},
+		4:  {"10.2", "1", "0.2", 0},
+		5:  {"10", "0.3", "0.1", 0},
+		6:  {"3.6", "1.3", "-0.3", 0},
+		7:  {"1", "2", "1", 0},
+		8:  {"3", "2", "-1", 0},
+		9:  {"5", "2", "1", 0},
+		10: {"-1", "2", "-1", 0},
+		11: {"-0", "1", "-0", 0},
+		12: {"1", "Inf", "1", 0},
+		13: {"Inf", "1", "NaN", decimal.InvalidOperation},
+		14: {"1", "0", "NaN", decimal.InvalidOperation | decimal.DivisionByZero},
+		15: {"1E+20", "3", "NaN", decimal.DivisionImpossible},
+		16: {"999999999", "1", "0", 0},
+		17: {"9999999995", "10", "NaN", decimal.DivisionImpossible},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		y, _ := new(decimal.Big).SetString(test.y)
+		z := decimal.WithContext(decimal.Context{Precision: 9, OperatingMode: decimal.GDA})
+		z.RemNear(x, y)
+		if test.r == "NaN" {
+			// Ignore the payload.
+			if !z.IsNaN(0) {
+				t.Fatalf("#%d: %s rem-near %s: wanted NaN, got %s", i, x, y, z)
+			}
+		} else if s := z.String(); s != test.r {
+			t.Fatalf("#%d: %s rem-near %s: wanted %q, got %q", i, x, y, test.r, s)
+		}
+		if c := z.Context.Conditions; c != test.c {
+			t.Fatalf("#%d: %s rem-near %s: wanted %q, got %q", i, x, y, test.c, c)
+		}
+
+		// z aliases y.
+		z.Context.Conditions = 0
+		z.Copy(y).RemNear(x, z)
+		if s := z.String(); s != test.r && !z.IsNaN(0) {
+			t.Fatalf("#%d: aliased: wanted %q, got %q", i, test.r, s)
+		}
+
+		// z aliases x.
+		z.Context.Conditions = 0
+		z.Copy(x).RemNear(z, y)
+		if s := z.String(); s != test.r && !z.IsNaN(0) {
+			t.Fatalf("#%d: aliased x: wanted %q, got %q", i, test.r, s)
+		}
+	}
+}
+
 func TestBig_Round(t *testing.T) {
 	for i, test := range [...]struct {
 		v   string
@@ -242,6 +432,62 @@ got   : %q
 	}
 }
 
+func TestBig_Quo_IdealExponent(t *testing.T) {
+	for i, test := range [...]struct {
+		ctx  decimal.Context
+		x, y string
+		res  string
+	}{
+		// Exact quotients are reduced to the ideal exponent, not past it.
+		0: {decimal.Context64, "1000", "1", "1000"},
+		1: {decimal.Context64, "1.20", "2", "0.60"},
+		2: {decimal.Context64, "2E+10", "2", "1E+10"},
+		3: {decimal.Context64, "100", "4", "25"},
+		// The divisor isn't clobbered before the result is rounded.
+		4: {decimal.Context128, "1", "3", "0.3333333333333333333333333333333333"},
+		5: {decimal.Context128, "2", "3", "0.6666666666666666666666666666666667"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		y, _ := new(decimal.Big).SetString(test.y)
+		z := decimal.WithContext(test.ctx)
+		if z.Quo(x, y); z.String() != test.res {
+			t.Fatalf("#%d: %s / %s: wanted %s, got %s", i, x, y, test.res, z)
+		}
+		if y.String() != test.y {
+			t.Fatalf("#%d: y changed to %s", i, y)
+		}
+	}
+}
+
+func TestBig_Quo_Subnormal(t *testing.T) {
+	for i, test := range [...]struct {
+		ctx  decimal.Context
+		x, y string
+		res  string
+		cond decimal.Condition
+	}{
+		// Rounded once, to etiny, not to the precision and then to etiny.
+		0: {decimal.Context64, "-6E-386", "-0.0263371984781218", "2.27814663164883E-384",
+			decimal.Inexact | decimal.Rounded | decimal.Subnormal | decimal.Underflow},
+		1: {decimal.Context64, "4.9979865591117E-109", "4.59386400015E+275", "1.08797007463619E-384",
+			decimal.Inexact | decimal.Rounded | decimal.Subnormal | decimal.Underflow},
+		2: {decimal.Context128, "-2E-5985", "7.163303E+1591", "-0E-6176",
+			decimal.Clamped | decimal.Inexact | decimal.Rounded | decimal.Subnormal | decimal.Underflow},
+		3: {decimal.Context64, "1E-390", "4", "2.5E-391", decimal.Subnormal},
+	} {
+		ctx := test.ctx
+		ctx.Traps = 0
+		x, _ := new(decimal.Big).SetString(test.x)
+		y, _ := new(decimal.Big).SetString(test.y)
+		z := decimal.WithContext(ctx)
+		z.Quo(x, y)
+		if z.String() != test.res || z.Context.Conditions != test.cond {
+			t.Fatalf("#%d: %s / %s: wanted %s (%s), got %s (%s)",
+				i, x, y, test.res, test.cond, z, z.Context.Conditions)
+		}
+	}
+}
+
 func TestBig_Scan(t *testing.T) {
 	// TODO(eric): write this test
 }
@@ -268,3 +514,58 @@ got   : %g
 }
 
 func isSpecial(f float64) bool { return math.IsInf(f, 0) || math.IsNaN(f) }
+
+func TestBig_SetNaNPayload(t *testing.T) {
+	x := new(decimal.Big).SetNaNPayload(false, 42)
+	if s := x.String(); s != "NaN42" {
+		t.Fatalf("wanted %q, got %q", "NaN42", s)
+	}
+
+	// Payloads propagate, signaling NaNs first.
+	z := new(decimal.Big).Add(x, decimal.New(1, 0))
+	if !z.IsNaN(+1) || z.Payload() != 42 || z.Context.Conditions != 0 {
+		t.Fatalf("NaN42 + 1: got %s (%s)", z, z.Context.Conditions)
+	}
+	y := new(decimal.Big).SetNaNPayload(true, 7)
+	z = new(decimal.Big).Mul(x, y)
+	if !z.IsNaN(+1) || z.Payload() != 7 || z.Context.Conditions != decimal.InvalidOperation {
+		t.Fatalf("NaN42 * sNaN7: got %s (%s)", z, z.Context.Conditions)
+	}
+
+	// NaNs without payloads are given diagnostic payloads.
+	z = new(decimal.Big).Quo(new(decimal.Big).SetNaN(false), decimal.New(1, 0))
+	if z.Payload() == 0 {
+		t.Fatalf("NaN / 1: wanted a payload, got %s", z)
+	}
+
+	for i, mode := range [...]decimal.OperatingMode{decimal.GDA, decimal.Go} {
+		x := decimal.WithContext(decimal.Context{OperatingMode: mode})
+		x.SetNaNPayload(true, 1234567890).CopySign(x, decimal.New(-1, 0))
+		b, err := x.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := string(b); s != "-sNaN1234567890" {
+			t.Fatalf("#%d: wanted %q, got %q", i, "-sNaN1234567890", s)
+		}
+		z := decimal.WithContext(x.Context)
+		if err := z.UnmarshalText(b); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if !z.IsNaN(-1) || !z.Signbit() || z.Payload() != 1234567890 {
+			t.Fatalf("#%d: round trip: got %s", i, z)
+		}
+	}
+}
+
+// TestBig_Size guards against accidentally growing Big, since users keep
+// millions of them in memory. Rarely used settings belong in Options.
+func TestBig_Size(t *testing.T) {
+	const max = 88
+	if bits.UintSize != 64 {
+		t.Skip("sizes are only checked on 64-bit platforms")
+	}
+	if n := unsafe.Sizeof(decimal.Big{}); n > max {
+		t.Fatalf("Big is %d bytes, wanted at most %d", n, max)
+	}
+}
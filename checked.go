@@ -0,0 +1,145 @@
+package decimal
+
+// Checked performs the same arithmetic as Context, but reports problems by
+// returning an error instead of through Conditions or, in the Go
+// OperatingMode, by panicking. A Context can be converted directly:
+//
+//   z, err := decimal.Checked(decimal.Context64).Quo(z, x, y)
+//   if errors.Is(err, decimal.DivisionByZero) {
+//       ...
+//   }
+//
+// Each method returns a non-nil *OpError if its operation raises a
+// Condition in Traps or if its result is a NaN. The Conditions raised are
+// still added to z's Context.
+type Checked Context
+
+// OpError describes a failed operation.
+type OpError struct {
+	// Op is the name of the operation, e.g. "Quo".
+	Op string
+
+	// Condition is every Condition raised by the operation, including those
+	// that were not trapped.
+	Condition Condition
+
+	// Payload is the payload of the result if it is a NaN, or zero
+	// otherwise.
+	Payload Payload
+}
+
+func (e *OpError) Error() string {
+	s := "decimal: " + e.Op
+	if e.Condition != 0 {
+		s += ": " + e.Condition.String()
+	}
+	if p := e.Payload.String(); p != "" {
+		s += " (" + p + ")"
+	}
+	return s
+}
+
+// Unwrap returns e.Condition, so errors.Is(err, DivisionByZero) reports
+// whether the operation raised DivisionByZero.
+func (e *OpError) Unwrap() error { return e.Condition }
+
+var _ error = (*OpError)(nil)
+
+// check runs fn, which performs op on z, and returns z along with an *OpError
+// if necessary.
+func (c Checked) check(op string, z *Big, fn func(c Context)) (r *Big, err error) {
+	old := z.Context.Conditions
+	z.Context.Conditions = 0
+	defer func() {
+		if v := recover(); v != nil {
+			if _, ok := v.(ErrNaN); !ok {
+				panic(v)
+			}
+		}
+		r = z
+		cond := z.Context.Conditions
+		z.Context.Conditions |= old
+		if cond&c.Traps != 0 || z.IsNaN(0) {
+			err = &OpError{Op: op, Condition: cond, Payload: z.Payload()}
+		}
+	}()
+	fn(Context(c))
+	return z, nil
+}
+
+// Add is like Context.Add, but returns an error.
+func (c Checked) Add(z, x, y *Big) (*Big, error) {
+	return c.check("Add", z, func(c Context) { c.Add(z, x, y) })
+}
+
+// FMA is like Context.FMA, but returns an error.
+func (c Checked) FMA(z, x, y, u *Big) (*Big, error) {
+	return c.check("FMA", z, func(c Context) { c.FMA(z, x, y, u) })
+}
+
+// Mul is like Context.Mul, but returns an error.
+func (c Checked) Mul(z, x, y *Big) (*Big, error) {
+	return c.check("Mul", z, func(c Context) { c.Mul(z, x, y) })
+}
+
+// Quantize is like Context.Quantize, but returns an error.
+func (c Checked) Quantize(z *Big, n int) (*Big, error) {
+	return c.check("Quantize", z, func(c Context) { c.Quantize(z, n) })
+}
+
+// Quo is like Context.Quo, but returns an error.
+func (c Checked) Quo(z, x, y *Big) (*Big, error) {
+	return c.check("Quo", z, func(c Context) { c.Quo(z, x, y) })
+}
+
+// QuoInt is like Context.QuoInt, but returns an error.
+func (c Checked) QuoInt(z, x, y *Big) (*Big, error) {
+	return c.check("QuoInt", z, func(c Context) { c.QuoInt(z, x, y) })
+}
+
+// Reduce is like Context.Reduce, but returns an error.
+func (c Checked) Reduce(z *Big) (*Big, error) {
+	return c.check("Reduce", z, func(c Context) { c.Reduce(z) })
+}
+
+// Rem is like Context.Rem, but returns an error.
+func (c Checked) Rem(z, x, y *Big) (*Big, error) {
+	return c.check("Rem", z, func(c Context) { c.Rem(z, x, y) })
+}
+
+// RemNear is like Context.RemNear, but returns an error.
+func (c Checked) RemNear(z, x, y *Big) (*Big, error) {
+	return c.check("RemNear", z, func(c Context) { c.RemNear(z, x, y) })
+}
+
+// Round is like Context.Round, but returns an error.
+func (c Checked) Round(z *Big) (*Big, error) {
+	return c.check("Round", z, func(c Context) { c.Round(z) })
+}
+
+// RoundToInt is like Context.RoundToInt, but returns an error.
+func (c Checked) RoundToInt(z *Big) (*Big, error) {
+	return c.check("RoundToInt", z, func(c Context) { c.RoundToInt(z) })
+}
+
+// Set is like Context.Set, but returns an error.
+func (c Checked) Set(z, x *Big) (*Big, error) {
+	return c.check("Set", z, func(c Context) { c.Set(z, x) })
+}
+
+// SetString is like Context.SetString, but returns an error instead of a
+// bool. An invalid string results in an error wrapping ConversionSyntax.
+func (c Checked) SetString(z *Big, s string) (*Big, error) {
+	return c.check("SetString", z, func(c Context) {
+		if _, ok := c.SetString(z, s); !ok {
+			z.Context.Conditions |= ConversionSyntax
+			z.form = qnan
+			z.compact = 0
+		}
+	})
+}
+
+// Sub is like Context.Sub, but returns an error.
+func (c Checked) Sub(z, x, y *Big) (*Big, error) {
+	return c.check("Sub", z, func(c Context) { c.Sub(z, x, y) })
+}
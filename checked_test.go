@@ -0,0 +1,107 @@
+package decimal_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestChecked(t *testing.T) {
+	for i, test := range [...]struct {
+		ctx  decimal.Context
+		op   string
+		x, y string
+		r    string
+		err  decimal.Condition // zero if no error is expected
+		msg  string
+	}{
+		0: {decimal.Context64, "/", "1", "3", "0.3333333333333333", 0, ""},
+		1: {decimal.Context64, "/", "1", "0", "Infinity", decimal.DivisionByZero,
+			"decimal: Quo: division by zero"},
+		2: {decimal.Context64, "/", "0", "0", "NaN", decimal.InvalidOperation | decimal.DivisionUndefined,
+			"decimal: Quo: division undefined, invalid operation (division of zero by zero)"},
+		3: {decimal.Context{}, "/", "1", "0", "Infinity", 0, ""},
+		4: {decimal.Context{}, "*", "Inf", "0", "NaN", decimal.InvalidOperation,
+			"decimal: Mul: invalid operation (multiplication of zero with infinity)"},
+		5: {decimal.Context{OperatingMode: decimal.Go}, "-", "Inf", "Inf", "NaN", decimal.InvalidOperation,
+			"decimal: Sub: invalid operation (subtraction of infinities with opposing signs)"},
+		6: {decimal.Context64, "%", "1", "0", "NaN", decimal.InvalidOperation | decimal.DivisionByZero,
+			"decimal: Rem: division by zero, invalid operation (remainder by zero)"},
+		7: {decimal.Context64, "s", "1.2.3", "", "NaN", decimal.ConversionSyntax,
+			"decimal: SetString: conversion syntax"},
+		8: {decimal.Context{Traps: decimal.Inexact}, "/", "2", "3", "0.6666666666666667", decimal.Inexact | decimal.Rounded,
+			"decimal: Quo: inexact, rounded"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		y, _ := new(decimal.Big).SetString(test.y)
+		z := decimal.WithContext(test.ctx)
+
+		c := decimal.Checked(test.ctx)
+		var err error
+		switch test.op {
+		case "-":
+			_, err = c.Sub(z, x, y)
+		case "*":
+			_, err = c.Mul(z, x, y)
+		case "/":
+			_, err = c.Quo(z, x, y)
+		case "%":
+			_, err = c.Rem(z, x, y)
+		case "s":
+			_, err = c.SetString(z, test.x)
+		}
+
+		if test.r == "NaN" {
+			if !z.IsNaN(0) {
+				t.Fatalf("#%d: wanted NaN, got %s", i, z)
+			}
+		} else if s := z.String(); s != test.r {
+			t.Fatalf("#%d: wanted %q, got %q", i, test.r, s)
+		}
+		if test.err == 0 {
+			if err != nil {
+				t.Fatalf("#%d: unexpected error: %v", i, err)
+			}
+			continue
+		}
+		var e *decimal.OpError
+		if !errors.As(err, &e) {
+			t.Fatalf("#%d: wanted *OpError, got %#v", i, err)
+		}
+		if e.Condition != test.err {
+			t.Fatalf("#%d: wanted %q, got %q", i, test.err, e.Condition)
+		}
+		if e.Payload != z.Payload() {
+			t.Fatalf("#%d: wanted payload %d, got %d", i, z.Payload(), e.Payload)
+		}
+		if s := err.Error(); s != test.msg {
+			t.Fatalf("#%d: wanted %q, got %q", i, test.msg, s)
+		}
+		for c := decimal.Condition(1); c != 0; c <<= 1 {
+			if got, want := errors.Is(err, c), test.err&c != 0; got != want {
+				t.Fatalf("#%d: errors.Is(err, %s): wanted %t, got %t", i, c, want, got)
+			}
+		}
+	}
+}
+
+func TestChecked_Conditions(t *testing.T) {
+	z := decimal.WithContext(decimal.Context64)
+	z.Context.Conditions = decimal.Clamped
+
+	c := decimal.Checked(decimal.Context64)
+	if _, err := c.Quo(z, decimal.New(1, 0), decimal.New(0, 0)); err == nil {
+		t.Fatal("expected an error")
+	}
+	// The previous Conditions are retained but not reported.
+	if want := decimal.Clamped | decimal.DivisionByZero; z.Context.Conditions != want {
+		t.Fatalf("wanted %q, got %q", want, z.Context.Conditions)
+	}
+	if !errors.Is(z.Context.Err(), decimal.DivisionByZero) {
+		t.Fatalf("Context.Err should match DivisionByZero")
+	}
+	if errors.Is(z.Context.Err(), decimal.Condition(0)) {
+		t.Fatalf("Context.Err should not match the zero Condition")
+	}
+}
@@ -2,7 +2,11 @@ package decimal
 
 import (
 	"fmt"
+	"math/big"
+	"math/bits"
+	"math/rand"
 	"strings"
+	"sync/atomic"
 
 	"github.com/ericlagergren/decimal/internal/c"
 )
@@ -18,25 +22,20 @@ const (
 )
 
 // Context is a per-decimal contextual object that governs specific operations.
+//
+// Every Big contains a Context, so its size matters. Settings that are rarely
+// used are kept out of line in Options.
 type Context struct {
-	// MaxScale overrides the MaxScale constant so long as it's in the range
-	// (0, MaxScale].
-	MaxScale int
-
-	// MinScale overrides the MaxScale constant so long as it's in the range
-	// [MinScale, 0).
-	MinScale int
-
 	// Precision is the Context's precision; that is, the maximum number of
 	// significant digits that may result from any arithmetic operation.
 	// Excluding any package-defined constants (e.g., ``UnlimitedPrecision''),
 	// if precision is not in the range [1, MaxPrecision] operations might
-	// result in an error. A precision of 0 will be interpreted as
-	// DefaultPrecision. For example,
+	// result in an error. A precision of 0 will be interpreted as the default
+	// Context's precision, or DefaultPrecision if it's 0 too. For example,
 	//
 	//   precision ==  4 // 4
 	//   precision == -4 // error
-	//   precision ==  0 // DefaultPrecision
+	//   precision ==  0 // DefaultPrecision (or DefaultContext().Precision)
 	//   precision == 12 // 12
 	//
 	Precision int
@@ -54,22 +53,183 @@ type Context struct {
 	// OperatingMode which dictates how the decimal operates under certain
 	// conditions. See OperatingMode for more information.
 	OperatingMode OperatingMode
+
+	// Clamp, if true, limits the exponent of a result to MaxScale -
+	// (Precision - 1), the largest exponent the IEEE 754-2008 interchange
+	// formats can encode. Results with larger exponents have their
+	// coefficients padded with zeros, and Clamped is raised. This is the
+	// General Decimal Arithmetic's "clamp=1" setting.
+	Clamp bool
+
+	// limits are the scale limits of Context32, Context64, or Context128.
+	// Unlike Options, they aren't lost when a copy's Options are replaced.
+	limits scaleLimits
+
+	// Options, if non-nil, are the Context's rarely used settings.
+	Options *Options
+}
+
+// Options are rarely used Context settings. They're kept out of line so they
+// don't increase the size of every Big, and one Options is meant to be shared
+// by many Contexts:
+//
+//   opts := &decimal.Options{FixedScale: true, Scale: 2}
+//   x := decimal.WithContext(decimal.Context{Precision: 19, Options: opts})
+//
+// An Options must not be modified while it's in use.
+type Options struct {
+	// MaxScale overrides the MaxScale constant so long as it's in the range
+	// (0, MaxScale].
+	MaxScale int
+
+	// MinScale overrides the MaxScale constant so long as it's in the range
+	// [MinScale, 0).
+	MinScale int
+
+	// Rand is the source of randomness for the Stochastic RoundingMode. If
+	// nil, the default source from math/rand is used. Set Rand to a seeded
+	// source for reproducible results, keeping in mind that the sources
+	// returned by math/rand.NewSource are not safe for concurrent use.
+	Rand rand.Source64
+
+	// FixedScale, if true, rounds results to exactly Scale digits after the
+	// decimal point instead of the Context's Precision significant digits.
+	// Precision instead limits the total number of digits, so a result may
+	// have at most Precision - Scale digits before the decimal point; results
+	// with more raise Overflow. UnlimitedPrecision removes the limit.
+	// MaxScale, MinScale, and Clamp are ignored.
+	//
+	// FixedScale is intended for arithmetic like that of SQL's NUMERIC(p, s)
+	// and is honored by Add, Sub, Mul, Quo, FMA, Set, and Round.
+	FixedScale bool
+
+	// Scale is the number of digits after the decimal point when FixedScale
+	// is true. It may be negative.
+	Scale int
+
+	// MaxDigits, if greater than zero, limits the number of digits of a
+	// coefficient that is parsed from a string or padded with zeros by
+	// Quantize, Add, Sub, or math.Pow. Exceeding it results in a NaN and
+	// raises InsufficientStorage instead of allocating. It's a safeguard for
+	// untrusted input, especially with UnlimitedPrecision.
+	MaxDigits int
+
+	// MaxScaleDiff, if greater than zero, limits the number of digits
+	// Quantize, SetScale, Add, and Sub may move a coefficient's decimal point
+	// by, e.g., when aligning 1E+9 and 1E-9. Exceeding it results in a NaN
+	// and raises InsufficientStorage.
+	MaxScaleDiff int
+
+	// Hook, if non-nil, is notified of every Context method, and every
+	// function in the math and misc packages, that raises a Condition. It's
+	// given the operation, its operands, its result, and the Conditions it
+	// raised, and may be used to audit how a result was rounded. Operations
+	// used internally by other operations, like the Quantize performed by
+	// RoundToInt or the Sqrt performed by math.Pow, aren't reported
+	// separately. See Recorder.
+	Hook Hook
+}
+
+// source returns c's Options.Rand, if any.
+func (c Context) source() rand.Source64 {
+	if c.Options != nil {
+		return c.Options.Rand
+	}
+	return nil
+}
+
+// hook returns c's Options.Hook, if any.
+func (c Context) hook() Hook {
+	if c.Options != nil {
+		return c.Options.Hook
+	}
+	return nil
 }
 
-func (c Context) maxScale() int {
-	if c.MaxScale != 0 {
-		return c.MaxScale
+// fixedScale returns c's Options.Scale and true if its FixedScale is set.
+func (c Context) fixedScale() (int, bool) {
+	if c.Options != nil && c.Options.FixedScale {
+		return c.Options.Scale, true
+	}
+	return 0, false
+}
+
+// withoutFixedScale returns c with its Options' FixedScale unset.
+func (c Context) withoutFixedScale() Context {
+	if _, ok := c.fixedScale(); ok {
+		o := *c.Options
+		o.FixedScale = false
+		c.Options = &o
+	}
+	return c
+}
+
+// maxDigits returns c's Options.MaxDigits, or zero.
+func (c Context) maxDigits() int {
+	if c.Options != nil {
+		return c.Options.MaxDigits
+	}
+	return 0
+}
+
+// MaxScale returns the largest scale c allows: its Options.MaxScale, the limit
+// of the predefined Context it was copied from, or the MaxScale constant.
+func (c Context) MaxScale() int {
+	if c.Options != nil && c.Options.MaxScale != 0 {
+		return c.Options.MaxScale
+	}
+	if c.limits != 0 {
+		return scaleLimitsTab[c.limits].max
 	}
 	return MaxScale
 }
 
-func (c Context) minScale() int {
-	if c.MinScale != 0 {
-		return c.MinScale
+// MinScale returns the smallest scale c allows: its Options.MinScale, the
+// limit of the predefined Context it was copied from, or the MinScale constant.
+func (c Context) MinScale() int {
+	if c.Options != nil && c.Options.MinScale != 0 {
+		return c.Options.MinScale
+	}
+	if c.limits != 0 {
+		return scaleLimitsTab[c.limits].min
 	}
 	return MinScale
 }
 
+// scaleLimits identifies the scale limits of a predefined Context. It's a
+// single byte, rather than the limits themselves, so that a Context is small
+// enough to be passed in registers.
+type scaleLimits uint8
+
+const (
+	noLimits scaleLimits = iota
+	limits32
+	limits64
+	limits128
+)
+
+var scaleLimitsTab = [...]struct{ max, min int }{
+	limits32:  {96, -95},
+	limits64:  {384, -383},
+	limits128: {6144, -6143},
+}
+
+// tooManyDigits reports whether n digits exceeds c's MaxDigits.
+func (c Context) tooManyDigits(n int) bool {
+	m := c.maxDigits()
+	return m > 0 && n > m
+}
+
+// tooLargeShift reports whether moving the decimal point by n digits exceeds
+// c's MaxScaleDiff.
+func (c Context) tooLargeShift(n int) bool {
+	if c.Options == nil {
+		return false
+	}
+	m := c.Options.MaxScaleDiff
+	return m > 0 && (n > m || n < -m)
+}
+
 // Err returns non-nil if there are any trapped exceptional conditions.
 func (c Context) Err() error {
 	if m := c.Conditions & c.Traps; m != 0 {
@@ -78,6 +238,64 @@ func (c Context) Err() error {
 	return nil
 }
 
+var (
+	// defaultContext holds the *Context set by SetDefaultContext, if any.
+	defaultContext atomic.Value
+
+	// defaultPrec is defaultContext's Precision. It's kept apart so that
+	// loading it is cheap enough for precision to be inlined.
+	defaultPrec int64
+)
+
+// DefaultContext returns the default Context. See SetDefaultContext.
+func DefaultContext() Context {
+	if c, ok := defaultContext.Load().(*Context); ok {
+		return *c
+	}
+	return Context{}
+}
+
+// SetDefaultContext sets the default Context, which is used in place of a zero
+// Context by literals created with New and SetString, and whose Precision is
+// used by any Context with a zero Precision, like that of a zero-valued Big.
+// Its Conditions are ignored. It returns a function that restores the previous
+// default Context, which is useful for scoping the change to a test:
+//
+//   defer decimal.SetDefaultContext(decimal.Context128)()
+//
+// Only the Precision applies to a Big whose Context is still the zero Context,
+// e.g. new(Big) or var x Big. Its RoundingMode, Traps, OperatingMode, and other
+// fields keep their zero values, even after arithmetic. Use New, SetString, or
+// WithContext(DefaultContext()) for a Big with the whole default Context.
+//
+// It's safe to call SetDefaultContext concurrently with operations, but
+// changing the default while they're running makes their precision
+// unpredictable, so it's best done during initialization.
+func SetDefaultContext(c Context) (restore func()) {
+	old := DefaultContext()
+	c.Conditions = 0
+	defaultContext.Store(&c)
+	atomic.StoreInt64(&defaultPrec, int64(c.Precision))
+	return func() {
+		defaultContext.Store(&old)
+		atomic.StoreInt64(&defaultPrec, int64(old.Precision))
+	}
+}
+
+// defaultPrecision returns the default Context's precision, or
+// DefaultPrecision if it's zero.
+func defaultPrecision() int {
+	if p := atomic.LoadInt64(&defaultPrec); p != 0 {
+		return int(p)
+	}
+	return DefaultPrecision
+}
+
+// isZero reports whether c, ignoring its Conditions, is the zero Context.
+func (c Context) isZero() bool {
+	return c == Context{Conditions: c.Conditions}
+}
+
 // WithContext is shorthand to create a Big decimal from a Context.
 func WithContext(c Context) *Big {
 	z := new(Big)
@@ -86,13 +304,14 @@ func WithContext(c Context) *Big {
 }
 
 // WithPrecision is shorthand to create a Big decimal with a given precision.
+// A precision of 0 is the default Context's precision at the time of the call.
 func WithPrecision(p int) *Big {
 	z := new(Big)
 	switch {
 	case p > 0 && p <= UnlimitedPrecision:
 		z.Context.Precision = p
 	case p == 0:
-		z.Context.Precision = DefaultPrecision
+		z.Context.Precision = defaultPrecision()
 	default:
 		z.setNaN(InvalidContext, qnan, invctxpgtu)
 	}
@@ -104,7 +323,13 @@ func WithPrecision(p int) *Big {
 
 // The following Contexts are based on IEEE 754R. Each Context's RoundingMode is
 // ToNearestEven, OperatingMode is GDA, and traps are set to every exception
-// other than Inexact, Rounded, and Subnormal.
+// other than Inexact, Rounded, and Subnormal. They don't set Clamp, so, for
+// example, Context64 allows exponents up to 384 rather than the 369 that the
+// decimal64 interchange format can encode. To match the interchange formats
+// exactly, set Clamp on a copy:
+//
+//   ctx := decimal.Context64
+//   ctx.Clamp = true
 var (
 	// Context32 is the IEEE 754R Decimal32 format.
 	Context32 = Context{
@@ -112,8 +337,7 @@ var (
 		RoundingMode:  ToNearestEven,
 		OperatingMode: GDA,
 		Traps:         ^(Inexact | Rounded | Subnormal),
-		MaxScale:      96,
-		MinScale:      -95,
+		limits:        limits32,
 	}
 
 	// Context64 is the IEEE 754R Decimal64 format.
@@ -122,8 +346,7 @@ var (
 		RoundingMode:  ToNearestEven,
 		OperatingMode: GDA,
 		Traps:         ^(Inexact | Rounded | Subnormal),
-		MaxScale:      384,
-		MinScale:      -383,
+		limits:        limits64,
 	}
 
 	// Context128 is the IEEE 754R Decimal128 format.
@@ -132,8 +355,7 @@ var (
 		RoundingMode:  ToNearestEven,
 		OperatingMode: GDA,
 		Traps:         ^(Inexact | Rounded | Subnormal),
-		MaxScale:      6144,
-		MinScale:      -6143,
+		limits:        limits128,
 	}
 
 	// ContextUnlimited provides unlimited precision decimals.
@@ -142,8 +364,6 @@ var (
 		RoundingMode:  ToNearestEven,
 		OperatingMode: GDA,
 		Traps:         ^(Inexact | Rounded | Subnormal),
-		MaxScale:      MaxScale,
-		MinScale:      MinScale,
 	}
 )
 
@@ -151,20 +371,53 @@ var (
 type RoundingMode uint8
 
 // The following rounding modes are supported.
+//
+// ToNearestTowardZero rounds to nearest, breaking ties toward zero. It's the
+// same as Python's ROUND_HALF_DOWN and Java's RoundingMode.HALF_DOWN.
+//
+// ZeroFiveUp rounds toward zero, unless the last digit of the truncated result
+// would be 0 or 5, in which case it rounds away from zero. It's the same as
+// Python's ROUND_05UP and, like ToNearestOdd, allows an intermediate result to
+// be rounded again without double rounding errors.
+//
+// ToNearestOdd rounds to nearest, breaking ties toward the odd neighbor.
+//
+// Stochastic rounds away from zero with a probability equal to the fraction of
+// a unit in the last place that was discarded, and toward zero otherwise. For
+// example, 1.25 rounded to one decimal place is 1.3 a quarter of the time and
+// 1.2 otherwise. The random numbers come from Options.Rand.
+//
+// Unnecessary asserts that results are exact, like Java's
+// RoundingMode.UNNECESSARY. If a result would have to be rounded, including
+// on overflow, it's instead a NaN and InvalidOperation, Inexact and Rounded
+// are raised. Since InvalidOperation is usually trapped, the failure can be
+// detected with Context.Err, errors.Is, or by a panic in the Go OperatingMode.
+// Formatting isn't arithmetic, so formatting a Big with fewer digits than it
+// has, e.g. with "%.2f" or a Pattern, rounds like ToNearestEven instead.
 const (
-	ToNearestEven RoundingMode = iota // == IEEE 754-2008 roundTiesToEven
-	ToNearestAway                     // == IEEE 754-2008 roundTiesToAway
-	ToZero                            // == IEEE 754-2008 roundTowardZero
-	AwayFromZero                      // no IEEE 754-2008 equivalent
-	ToNegativeInf                     // == IEEE 754-2008 roundTowardNegative
-	ToPositiveInf                     // == IEEE 754-2008 roundTowardPositive
+	ToNearestEven       RoundingMode = iota // == IEEE 754-2008 roundTiesToEven
+	ToNearestAway                           // == IEEE 754-2008 roundTiesToAway
+	ToZero                                  // == IEEE 754-2008 roundTowardZero
+	AwayFromZero                            // no IEEE 754-2008 equivalent
+	ToNegativeInf                           // == IEEE 754-2008 roundTowardNegative
+	ToPositiveInf                           // == IEEE 754-2008 roundTowardPositive
+	ToNearestTowardZero                     // no IEEE 754-2008 equivalent
+	ZeroFiveUp                              // no IEEE 754-2008 equivalent
+	ToNearestOdd                            // no IEEE 754-2008 equivalent
+	Stochastic                              // no IEEE 754-2008 equivalent
+	Unnecessary                             // no IEEE 754-2008 equivalent
 
 	unnecessary // placeholder for x / y with UnlimitedPrecision.
 )
 
 //go:generate stringer -type RoundingMode
 
-func (m RoundingMode) needsInc(odd bool, r int, pos bool) bool {
+// needsInc reports whether a truncated coefficient with the least
+// significant digit d needs to be incremented in order to be rounded. It must
+// only be called if non-zero digits were discarded. The Stochastic mode is
+// handled separately since it depends on the exact value of the discarded
+// digits.
+func (m RoundingMode) needsInc(d uint64, r int, pos bool) bool {
 	switch m {
 	case AwayFromZero:
 		return true // always up
@@ -174,22 +427,58 @@ func (m RoundingMode) needsInc(odd bool, r int, pos bool) bool {
 		return pos // up if positive
 	case ToNegativeInf:
 		return !pos // down if negative
+	case ZeroFiveUp:
+		return d == 0 || d == 5 // up if the last digit is 0 or 5
 
-	//  r <  0: closer to higher
+	//  r <  0: closer to lower
 	//  r == 0: halfway
-	//  r >  0: closer to lower
+	//  r >  0: closer to higher
 	case ToNearestEven:
 		if r != 0 {
 			return r > 0
 		}
-		return odd
+		return d&1 != 0
 	case ToNearestAway:
 		return r >= 0
+	case ToNearestTowardZero:
+		return r > 0
+	case ToNearestOdd:
+		if r != 0 {
+			return r > 0
+		}
+		return d&1 == 0
 	default:
 		return false
 	}
 }
 
+// stochastic reports whether a truncated coefficient needs to be incremented
+// in order to be rounded with the Stochastic mode, given that r/y of a unit in
+// the last place was discarded.
+func stochastic(src rand.Source64, r, y uint64) bool {
+	// Increment if u/2**64 < r/y, where u is uniformly distributed.
+	hi, _ := bits.Mul64(random(src), y)
+	return hi < r
+}
+
+// stochasticBig is like stochastic, but for big.Ints.
+func stochasticBig(src rand.Source64, r, y *big.Int) bool {
+	var u, v big.Int
+	u.SetUint64(random(src))
+	u.Mul(&u, y)
+	v.Lsh(r, 64)
+	return u.CmpAbs(&v) < 0
+}
+
+// random returns a uniformly distributed uint64 from src, or from the default
+// source if src is nil.
+func random(src rand.Source64) uint64 {
+	if src != nil {
+		return src.Uint64()
+	}
+	return rand.Uint64()
+}
+
 // OperatingMode dictates how the decimal approaches specific non-numeric
 // operations like conversions to strings and panicking on NaNs.
 type OperatingMode uint8
@@ -245,7 +534,8 @@ const (
 	// exact, or when the Overflow/Underflow Conditions occur.
 	Inexact
 	// InsufficientStorage occurs when the system doesn't have enough storage
-	// (i.e. memory) to store the decimal.
+	// (i.e. memory) to store the decimal, or when a result would exceed
+	// Options.MaxDigits or Options.MaxScaleDiff.
 	InsufficientStorage
 	// InvalidContext occurs when an invalid context was detected during an
 	// operation. This might occur if, for example, an invalid RoundingMode was
@@ -286,6 +576,14 @@ const (
 
 func (c Condition) Error() string { return c.String() }
 
+// Is reports whether target is a Condition whose flags are all set in c. It
+// allows errors.Is(err, DivisionByZero) to match an error wrapping
+// DivisionByZero|Inexact, for example.
+func (c Condition) Is(target error) bool {
+	t, ok := target.(Condition)
+	return ok && t != 0 && c&t == t
+}
+
 func (c Condition) String() string {
 	if c == 0 {
 		return ""
@@ -1,6 +1,12 @@
 package decimal
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
 
 func TestCondition_String(t *testing.T) {
 	for i, test := range [...]struct {
@@ -18,3 +24,620 @@ func TestCondition_String(t *testing.T) {
 		}
 	}
 }
+
+func TestContext_fix(t *testing.T) {
+	for i, test := range [...]struct {
+		x    *Big
+		mode RoundingMode
+		r    string
+		c    Condition
+	}{
+		// Overflow rounds to infinity or the largest finite number, depending
+		// on the direction of the rounding mode.
+		0: {New(9, -10), ToNearestEven, "Infinity", Overflow | Inexact | Rounded},
+		1: {New(9, -10), AwayFromZero, "Infinity", Overflow | Inexact | Rounded},
+		2: {New(9, -10), ToZero, "9.99E+9", Overflow | Inexact | Rounded},
+		3: {New(9, -10), ToNegativeInf, "9.99E+9", Overflow | Inexact | Rounded},
+		4: {New(-9, -10), ToPositiveInf, "-9.99E+9", Overflow | Inexact | Rounded},
+		5: {New(-9, -10), ToNegativeInf, "-Infinity", Overflow | Inexact | Rounded},
+		6: {New(-9, -10), ToZero, "-9.99E+9", Overflow | Inexact | Rounded},
+
+		// Subnormal results are rounded against the Context's MinScale, and
+		// only underflow if they're inexact.
+		7:  {New(1, 11), ToNearestEven, "1E-11", Subnormal},
+		8:  {New(123, 12), ToNearestEven, "1.2E-10", Underflow | Subnormal | Inexact | Rounded},
+		9:  {New(15, 12), ToNearestEven, "2E-11", Underflow | Subnormal | Inexact | Rounded},
+		10: {New(4, 12), ToNearestEven, "0E-11", Underflow | Subnormal | Inexact | Rounded | Clamped},
+		11: {New(1, 30), ToPositiveInf, "1E-11", Underflow | Subnormal | Inexact | Rounded},
+		12: {New(1, 30), ToNearestEven, "0E-11", Underflow | Subnormal | Inexact | Rounded | Clamped},
+	} {
+		ctx := Context{
+			Precision:     3,
+			RoundingMode:  test.mode,
+			OperatingMode: GDA,
+			Options:       &Options{MaxScale: 9, MinScale: -9},
+		}
+		z := WithContext(ctx)
+		ctx.Mul(z, test.x, New(1, 0))
+		if s := z.String(); s != test.r {
+			t.Fatalf("#%d: %s [%s]: wanted %q, got %q", i, test.x, test.mode, test.r, s)
+		}
+		if c := z.Context.Conditions; c != test.c {
+			t.Fatalf("#%d: %s [%s]: wanted %q, got %q", i, test.x, test.mode, test.c, c)
+		}
+	}
+}
+
+func TestContext_Options(t *testing.T) {
+	// Replacing a predefined Context's Options keeps its exponent limits.
+	ctx := Context64
+	ctx.Options = &Options{MaxDigits: 40}
+	z := WithContext(ctx)
+	ctx.Mul(z, New(1, -384), New(10, 0))
+	if !z.IsInf(+1) || z.Context.Conditions&Overflow == 0 {
+		t.Fatalf("wanted Infinity and %q, got %s and %q",
+			Overflow, z, z.Context.Conditions)
+	}
+
+	// Options.MaxScale and Options.MinScale override them.
+	ctx.Options = &Options{MaxScale: 400, MinScale: -400}
+	if max, min := ctx.MaxScale(), ctx.MinScale(); max != 400 || min != -400 {
+		t.Fatalf("wanted 400 and -400, got %d and %d", max, min)
+	}
+	if max, min := Context64.MaxScale(), Context64.MinScale(); max != 384 || min != -383 {
+		t.Fatalf("Context64: wanted 384 and -383, got %d and %d", max, min)
+	}
+}
+
+func TestContext_Clamp(t *testing.T) {
+	x := New(1, -380)
+
+	// Context64 doesn't clamp, so large exponents are left alone.
+	z := new(Big)
+	Context64.Set(z, x)
+	if s := z.String(); s != "1E+380" || z.Context.Err() != nil {
+		t.Fatalf("Context64: wanted 1E+380 and no error, got %s and %v", s, z.Context.Err())
+	}
+
+	ctx := Context64
+	ctx.Clamp = true
+	ctx.Traps = 0
+	z = new(Big)
+	ctx.Set(z, x)
+	if s := z.String(); s != "1.00000000000E+380" || z.Context.Conditions != Clamped {
+		t.Fatalf("clamped: wanted 1.00000000000E+380 (clamped), got %s (%s)",
+			s, z.Context.Conditions)
+	}
+}
+
+func TestContext_FixedScale(t *testing.T) {
+	for i, test := range [...]struct {
+		op    string
+		x, y  string
+		scale int
+		prec  int
+		mode  RoundingMode
+		r     string
+		c     Condition
+	}{
+		0:  {"+", "1.5", "2.25", 4, 19, ToNearestEven, "3.7500", 0},
+		1:  {"+", "1E+14", "0.00005", 4, 19, ToNearestEven, "100000000000000.0000", Inexact | Rounded},
+		2:  {"+", "1E+14", "0.00015", 4, 19, ToNearestEven, "100000000000000.0002", Inexact | Rounded},
+		3:  {"-", "0.0001", "0.00015", 4, 19, ToZero, "-0.0000", Inexact | Rounded},
+		4:  {"*", "1.2345", "1.2345", 4, 19, ToNearestEven, "1.5240", Inexact | Rounded},
+		5:  {"*", "123456789012.34", "1000", 4, 19, ToNearestEven, "123456789012340.0000", 0},
+		6:  {"*", "123456789012.34", "10000", 4, 19, ToNearestEven, "Infinity", Overflow | Inexact | Rounded},
+		7:  {"*", "123456789012.34", "10000", 4, 19, ToZero, "999999999999999.9999", Overflow | Inexact | Rounded},
+		8:  {"/", "1", "3", 4, 19, ToNearestEven, "0.3333", Inexact | Rounded},
+		9:  {"/", "2", "3", 2, 19, ToNearestEven, "0.67", Inexact | Rounded},
+		10: {"/", "1E+20", "3", 2, UnlimitedPrecision, ToNearestEven, "33333333333333333333.33", Inexact | Rounded},
+		11: {"/", "-10", "4", 0, 19, ToNearestEven, "-2", Inexact | Rounded},
+		12: {"/", "0", "7", 3, 19, ToNearestEven, "0.000", 0},
+		13: {"/", "9.9995", "1", 3, 19, ToNearestAway, "10.000", Inexact | Rounded},
+		14: {"=", "1234.5678", "", -2, 19, ToNearestEven, "1.2E+3", Inexact | Rounded},
+		15: {"=", "12", "", 2, 3, ToNearestEven, "Infinity", Overflow | Inexact | Rounded},
+		16: {"fma", "1.5", "1.5", 1, 19, ToNearestEven, "3.8", Inexact | Rounded},
+		// Huge exponents overflow before the coefficient is padded.
+		17: {"=", "1E+100000000", "", 2, 19, ToNearestEven, "Infinity", Overflow | Inexact | Rounded},
+		18: {"=", "-1E+2000000", "", 2, 19, ToZero, "-99999999999999999.99", Overflow | Inexact | Rounded},
+		19: {"/", "1", "1E-100000000", 2, 19, ToNearestEven, "Infinity", Overflow | Inexact | Rounded},
+		20: {"/", "1E-100000000", "3", 2, 19, ToNearestEven, "0.00", Inexact | Rounded},
+		21: {"/", "1E-100000000", "3", 2, 19, ToPositiveInf, "0.01", Inexact | Rounded},
+	} {
+		ctx := Context{
+			Precision:     test.prec,
+			RoundingMode:  test.mode,
+			OperatingMode: GDA,
+			Options:       &Options{FixedScale: true, Scale: test.scale},
+		}
+		x, _ := new(Big).SetString(test.x)
+		y, _ := new(Big).SetString(test.y)
+		z := WithContext(ctx)
+		switch test.op {
+		case "+":
+			z.Add(x, y)
+		case "-":
+			z.Sub(x, y)
+		case "*":
+			z.Mul(x, y)
+		case "/":
+			z.Quo(x, y)
+		case "=":
+			ctx.Set(z, x)
+		case "fma":
+			z.FMA(x, y, New(15, 1))
+		}
+		if s := z.String(); s != test.r {
+			t.Fatalf("#%d: %s %s %s: wanted %q, got %q", i, x, test.op, y, test.r, s)
+		}
+		if c := z.Context.Conditions; c != test.c {
+			t.Fatalf("#%d: %s %s %s: wanted %q, got %q", i, x, test.op, y, test.c, c)
+		}
+	}
+
+	// Big.Round rounds to a precision, without changing the shared Options.
+	opts := &Options{FixedScale: true, Scale: 2}
+	z := WithContext(Context{Options: opts})
+	z.SetString("1.234")
+	if s := z.Round(2).String(); s != "1.2" || !opts.FixedScale {
+		t.Fatalf("Round: wanted \"1.2\" with FixedScale set, got %q and %t", s, opts.FixedScale)
+	}
+}
+
+func TestContext_RoundingMode(t *testing.T) {
+	const big = "12345678901234567890123"
+	for i, test := range [...]struct {
+		x    string
+		prec int
+		mode RoundingMode
+		r    string
+	}{
+		0:  {"12.5", 2, ToNearestTowardZero, "12"},
+		1:  {"-12.5", 2, ToNearestTowardZero, "-12"},
+		2:  {"12.51", 2, ToNearestTowardZero, "13"},
+		3:  {"12.5", 2, ToNearestOdd, "13"},
+		4:  {"13.5", 2, ToNearestOdd, "13"},
+		5:  {"-12.5", 2, ToNearestOdd, "-13"},
+		6:  {"13.6", 2, ToNearestOdd, "14"},
+		7:  {"10.9", 2, ZeroFiveUp, "11"},
+		8:  {"11.9", 2, ZeroFiveUp, "11"},
+		9:  {"-15.1", 2, ZeroFiveUp, "-16"},
+		10: {"15", 2, ZeroFiveUp, "15"},
+		11: {big + ".5", 23, ToNearestTowardZero, big},
+		12: {big + "2.5", 24, ToNearestOdd, big + "3"},
+		13: {big + "0.1", 24, ZeroFiveUp, big + "1"},
+		14: {big + "6.1", 24, ZeroFiveUp, big + "6"},
+		15: {"9.95", 2, ZeroFiveUp, "9.9"},
+		16: {"9.5", 1, ToNearestOdd, "9"},
+	} {
+		ctx := Context{Precision: test.prec, RoundingMode: test.mode}
+		x, _ := new(Big).SetString(test.x)
+		if s := ctx.Round(x).String(); s != test.r {
+			t.Fatalf("#%d: wanted %q, got %q", i, test.r, s)
+		}
+	}
+}
+
+func TestContext_Stochastic(t *testing.T) {
+	const N = 10000
+	for i, test := range [...]struct {
+		x, y string
+		prec int
+		lo   string
+		p    float64
+	}{
+		0: {"1", "8", 1, "0.1", 0.25},
+		1: {"-2", "3", 1, "-0.6", 2.0 / 3},
+		2: {"1", "1234567890123456789012345", 26, "8.1000000729000006633900104E-25", 0.911},
+	} {
+		ctx := Context{
+			Precision:    test.prec,
+			RoundingMode: Stochastic,
+			Options:      &Options{Rand: rand.New(rand.NewSource(int64(i)))},
+		}
+		x, _ := new(Big).SetString(test.x)
+		y, _ := new(Big).SetString(test.y)
+		lo, _ := new(Big).SetString(test.lo)
+		var n int
+		for j := 0; j < N; j++ {
+			z := ctx.Quo(new(Big), x, y)
+			if z.Cmp(lo) != 0 {
+				n++
+			}
+		}
+		if p := float64(n) / N; math.Abs(p-test.p) > 0.02 {
+			t.Fatalf("#%d: rounded away from zero with p = %.3f, wanted %.3f",
+				i, p, test.p)
+		}
+	}
+
+	// Tiny addends don't make rounding up more likely.
+	ctx := Context{
+		Precision:    5,
+		RoundingMode: Stochastic,
+		Options:      &Options{Rand: rand.New(rand.NewSource(1))},
+	}
+	for j := 0; j < N; j++ {
+		z := ctx.Add(new(Big), New(1, 0), New(1, 22))
+		if s := z.String(); s != "1.0000" {
+			t.Fatalf("1 + 1E-22: wanted 1.0000, got %s", s)
+		}
+	}
+
+	// The same seed results in the same choices.
+	x, y := New(1, 0), New(3, 0)
+	var z1, z2 Big
+	for j := 0; j < 100; j++ {
+		ctx1 := Context{Precision: 3, RoundingMode: Stochastic,
+			Options: &Options{Rand: rand.New(rand.NewSource(int64(j)))}}
+		ctx2 := Context{Precision: 3, RoundingMode: Stochastic,
+			Options: &Options{Rand: rand.New(rand.NewSource(int64(j)))}}
+		for k := 0; k < 5; k++ {
+			ctx1.Quo(&z1, x, y)
+			ctx2.Quo(&z2, x, y)
+			if z1.Cmp(&z2) != 0 {
+				t.Fatalf("seed %d: %s != %s", j, &z1, &z2)
+			}
+		}
+	}
+}
+
+func TestContext_Unnecessary(t *testing.T) {
+	const fail = InvalidOperation | Inexact | Rounded
+	for i, test := range [...]struct {
+		op   string
+		x, y string
+		prec int
+		r    string
+		c    Condition
+	}{
+		0:  {"+", "1.25", "2.5", 3, "3.75", 0},
+		1:  {"+", "1.25", "2.75", 3, "4.00", 0},
+		2:  {"+", "1.25", "9.5", 3, "NaN", fail},
+		3:  {"+", "1E+20", "1", 19, "NaN", fail},
+		4:  {"*", "1.5", "1.5", 3, "2.25", 0},
+		5:  {"*", "1.5", "1.55", 3, "NaN", fail},
+		6:  {"*", "123456789012345678901234567890", "10", 31, "1234567890123456789012345678900", 0},
+		7:  {"*", "123456789012345678901234567891", "11", 30, "NaN", fail},
+		8:  {"*", "9E+1000", "10", 3, "NaN", Overflow | fail},
+		9:  {"/", "1", "8", 3, "0.125", 0},
+		10: {"/", "1", "8", 2, "NaN", fail},
+		11: {"/", "100", "3", 16, "NaN", fail},
+		12: {"/", "1", "3", UnlimitedPrecision, "NaN", fail | InvalidContext | InsufficientStorage},
+		13: {"/", "1", "1234567890123456789012345678900", 40, "NaN", fail},
+		14: {"/", "6", "1234567890123456789012345678900", UnlimitedPrecision, "NaN", fail | InvalidContext | InsufficientStorage},
+		15: {"q", "1.2300", "2", 16, "1.23", Rounded},
+		16: {"q", "1.2345", "2", 16, "NaN", fail},
+		17: {"q", "1.2", "3", 16, "1.200", 0},
+		18: {"s", "1.2300", "", 3, "1.23", Rounded},
+		19: {"s", "1.2345", "", 3, "NaN", fail},
+		20: {"s", "12345678901234567890123456789012345", "", 34, "NaN", fail},
+	} {
+		ctx := Context{
+			Precision:     test.prec,
+			RoundingMode:  Unnecessary,
+			OperatingMode: GDA,
+			Options:       &Options{MaxScale: 1000, MinScale: -1000},
+		}
+		x, _ := new(Big).SetString(test.x)
+		y, _ := new(Big).SetString(test.y)
+		z := WithContext(ctx)
+		switch test.op {
+		case "+":
+			z.Add(x, y)
+		case "*":
+			z.Mul(x, y)
+		case "/":
+			z.Quo(x, y)
+		case "q":
+			n, _ := y.Int64()
+			z.Copy(x).Quantize(int(n))
+		case "s":
+			ctx.SetString(z, test.x)
+		}
+		if test.r == "NaN" {
+			if !z.IsNaN(0) {
+				t.Fatalf("#%d: %s %s %s: wanted NaN, got %q", i, x, test.op, y, z)
+			}
+		} else if s := z.String(); s != test.r {
+			t.Fatalf("#%d: %s %s %s: wanted %q, got %q", i, x, test.op, y, test.r, s)
+		}
+		if c := z.Context.Conditions; c != test.c {
+			t.Fatalf("#%d: %s %s %s: wanted %q, got %q", i, x, test.op, y, test.c, c)
+		}
+		if z.IsNaN(0) {
+			want := roundunnec
+			if test.prec == UnlimitedPrecision {
+				want = quotermexp
+			}
+			if p := z.Payload(); p != want {
+				t.Fatalf("#%d: %s %s %s: wanted payload %q, got %q", i, x, test.op, y, want, p)
+			}
+		}
+	}
+
+	// Formatting isn't arithmetic, so it rounds half even rather than fail,
+	// and doesn't modify x.
+	for i, test := range [...]struct {
+		x, f, r string
+	}{
+		0:  {"1.25", "%.2f", "1.25"},
+		1:  {"1.25", "%.3f", "1.250"},
+		2:  {"1.25", "%.1f", "1.2"},
+		3:  {"1.35", "%.1f", "1.4"},
+		4:  {"-1.25", "%.2e", "-1.2e0"},
+		5:  {"1.25", "f1", "1.2"},
+		6:  {"1.25", "0.00", "1.25"},
+		7:  {"-1.25", "0.0", "-1.2"},
+		8:  {"1.25", "0.0E0", "1.2E0"},
+		9:  {"100", "0E0", "1E2"},
+		10: {"0.6", "0", "1"},
+		11: {"0.000001", "0", "0"},
+	} {
+		x := WithContext(Context{RoundingMode: Unnecessary})
+		x.SetString(test.x)
+		var r string
+		switch test.f[0] {
+		case '%':
+			r = fmt.Sprintf(test.f, x)
+		case 'f':
+			r = x.Text('f', int(test.f[1]-'0'))
+		default:
+			r = MustCompilePattern(test.f).Format(x)
+		}
+		if r != test.r || x.Context.Conditions != 0 {
+			t.Fatalf("#%d: %s with %q: wanted %q, got %q (%s)",
+				i, test.x, test.f, test.r, r, x.Context.Conditions)
+		}
+	}
+
+	// The failure is trapped.
+	ctx := Context{RoundingMode: Unnecessary, OperatingMode: GDA, Traps: InvalidOperation}
+	z := WithContext(ctx)
+	z.Quo(New(1, 0), New(3, 0))
+	if err := z.Context.Err(); !errors.Is(err, InvalidOperation) {
+		t.Fatalf("wanted an error wrapping InvalidOperation, got %v", err)
+	}
+	if _, err := Checked(ctx).Quo(new(Big), New(1, 0), New(3, 0)); !errors.Is(err, Inexact) {
+		t.Fatalf("wanted an error wrapping Inexact, got %v", err)
+	}
+	if _, err := Checked(ctx).Quo(new(Big), New(1, 0), New(4, 0)); err != nil {
+		t.Fatalf("wanted nil, got %v", err)
+	}
+
+	// Go mode panics.
+	func() {
+		defer func() {
+			if _, ok := recover().(ErrNaN); !ok {
+				t.Fatal("wanted an ErrNaN panic")
+			}
+		}()
+		z := WithContext(Context{RoundingMode: Unnecessary, OperatingMode: Go})
+		z.Quo(New(1, 0), New(3, 0))
+	}()
+}
+
+func TestContext_Storage(t *testing.T) {
+	for i, test := range [...]struct {
+		op     string
+		x, y   string
+		digits int
+		diff   int
+		r      string
+		c      Condition
+	}{
+		0:  {"s", "12345678901234567890", "", 20, 0, "12345678901234567890", 0},
+		1:  {"s", "123456789012345678901", "", 20, 0, "NaN", InsufficientStorage},
+		2:  {"s", "1234.5678901234567890123", "", 22, 0, "NaN", InsufficientStorage},
+		3:  {"s", "12345", "", 4, 0, "NaN", InsufficientStorage},
+		4:  {"s", "123456789012345678901234567890", "", 30, 0, "123456789012345678901234567890", 0},
+		5:  {"s", "1234567890123456789012345678901", "", 30, 0, "NaN", InsufficientStorage},
+		6:  {"s", "1234567890123456789012345678901234567890", "", 40, 0, "1234567890123456789012345678901234567890", 0},
+		7:  {"s", "12345678901234567890123456789012345678901", "", 40, 0, "NaN", InsufficientStorage},
+		8:  {"s", "1234567890.12345678901234567890", "", 30, 0, "1234567890.12345678901234567890", 0},
+		9:  {"s", "1234567890.123456789012345678901", "", 30, 0, "NaN", InsufficientStorage},
+		10: {"s", "1e-999999999", "", 4, 0, "1E-999999999", 0},
+		11: {"q", "1e-999", "0", 0, 100, "NaN", InsufficientStorage},
+		12: {"q", "1e-99", "0", 0, 100, "0", Inexact | Rounded},
+		13: {"q", "1", "999", 0, 100, "NaN", InsufficientStorage},
+		14: {"q", "1", "99", 50, 0, "NaN", InsufficientStorage},
+		15: {"q", "1", "49", 50, 0, "1.0000000000000000000000000000000000000000000000000", 0},
+		16: {"+", "1", "1e-999", 0, 100, "NaN", InsufficientStorage},
+		17: {"+", "1", "1e-99", 0, 100, "1.000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001", 0},
+		18: {"-", "1e+999", "1", 0, 100, "NaN", InsufficientStorage},
+		19: {"+", "123", "1e-99", 100, 0, "NaN", InsufficientStorage},
+		20: {"+", "123", "1e-97", 100, 0, "123.0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001", 0},
+		21: {"scale", "1.5", "-999", 0, 100, "NaN", InsufficientStorage},
+		22: {"scale", "1.5", "99", 0, 100, "1.5E-98", 0},
+	} {
+		ctx := Context{
+			Precision:     UnlimitedPrecision,
+			OperatingMode: GDA,
+			Options:       &Options{MaxDigits: test.digits, MaxScaleDiff: test.diff},
+		}
+		z := WithContext(ctx)
+		x, _ := new(Big).SetString(test.x)
+		y, _ := new(Big).SetString(test.y)
+		switch test.op {
+		case "s":
+			z.SetString(test.x)
+		case "q":
+			n, _ := y.Int64()
+			z.Copy(x).Quantize(int(n))
+		case "+":
+			z.Add(x, y)
+		case "-":
+			z.Sub(x, y)
+		case "scale":
+			n, _ := y.Int64()
+			z.Copy(x).SetScale(int(n))
+		}
+		if test.r == "NaN" {
+			if !z.IsNaN(0) {
+				t.Fatalf("#%d: wanted NaN, got %s", i, z)
+			}
+		} else if s := z.String(); s != test.r {
+			t.Fatalf("#%d: wanted %q, got %q", i, test.r, s)
+		}
+		if c := z.Context.Conditions; c != test.c {
+			t.Fatalf("#%d: wanted %q, got %q", i, test.c, c)
+		}
+	}
+}
+
+func TestContext_Hook(t *testing.T) {
+	var r Recorder
+	ctx := Context{Precision: 5, Options: &Options{Hook: &r}}
+
+	z := new(Big)
+	ctx.Quo(z, New(1, 0), New(3, 0))
+	ctx.Add(z, New(1, 0), New(2, 0)) // exact, so not recorded
+	z.Context.Conditions = Clamped
+	ctx.RoundToInt(z.SetMantScale(15, 1))
+	if z.Context.Conditions != Clamped|Inexact|Rounded {
+		t.Fatalf("conditions not preserved: %s", z.Context.Conditions)
+	}
+	ctx.FMA(z.SetMantScale(1, 0), New(12345, 0), New(10, 0), z)
+	ctx.SetString(z, "1.234567")
+	ctx.RoundToIntegralValue(z) // inexact, but doesn't raise anything
+
+	want := []string{
+		"Quo(1, 3) = 0.33333 [5, ToNearestEven]: inexact, rounded",
+		"RoundToInt(1.5) = 2 [5, ToNearestEven]: inexact, rounded",
+		"FMA(12345, 10, 1) = 1.2345E+5 [5, ToNearestEven]: inexact, rounded",
+		"SetString(1.234567) = 1.2346 [5, ToNearestEven]: inexact, rounded",
+	}
+	events := r.Events()
+	if len(events) != len(want) {
+		t.Fatalf("wanted %d events, got %d: %v", len(want), len(events), events)
+	}
+	for i, e := range events {
+		if s := e.String(); s != want[i] {
+			t.Fatalf("#%d: wanted %q, got %q", i, want[i], s)
+		}
+		if e.Context.Options.Hook != nil {
+			t.Fatalf("#%d: Event.Context.Options.Hook should be nil", i)
+		}
+	}
+
+	r.Reset()
+	r.Mask = Inexact
+	ctx.Round(z.SetMantScale(1230000, 6)) // rounded, but exact
+	ctx.Quantize(z.SetMantScale(125, 2), 1)
+	events = r.Events()
+	if len(events) != 1 {
+		t.Fatalf("wanted 1 event, got %d: %v", len(events), events)
+	}
+	if s, want := events[0].String(), "Quantize(1.25, 0.1) = 1.2 [5, ToNearestEven]: inexact, rounded"; s != want {
+		t.Fatalf("wanted %q, got %q", want, s)
+	}
+}
+
+func TestContext_CmpSignal(t *testing.T) {
+	x, y := New(1, 0), new(Big).SetNaN(false)
+
+	ctx := Context{Traps: InvalidOperation}
+	if r, ok := ctx.CmpSignal(x, New(2, 0)); r != -1 || !ok {
+		t.Fatalf("1 cmp 2: wanted -1 and true, got %d and %t", r, ok)
+	}
+	if r, ok := ctx.CmpSignal(x, y); r != 0 || ok {
+		t.Fatalf("1 cmp NaN: wanted 0 and false, got %d and %t", r, ok)
+	}
+	if err := ctx.Err(); err != InvalidOperation {
+		t.Fatalf("wanted %v, got %v", InvalidOperation, err)
+	}
+	if x.Context.Conditions != 0 || y.Context.Conditions != 0 {
+		t.Fatalf("operands were modified: %q and %q",
+			x.Context.Conditions, y.Context.Conditions)
+	}
+
+	defer func() {
+		if _, ok := recover().(ErrNaN); !ok {
+			t.Fatal("wanted a panic in the Go OperatingMode")
+		}
+	}()
+	ctx = Context{OperatingMode: Go}
+	ctx.CmpSignal(y, x)
+}
+
+func TestContext_RoundToIntegralValue(t *testing.T) {
+	ctx := Context{Traps: Inexact | Rounded}
+	for _, mode := range [...]OperatingMode{GDA, Go} {
+		ctx.OperatingMode = mode
+		z := New(25, 1)
+		ctx.RoundToIntegralValue(z)
+		if s := z.String(); s != "2" || z.Context.Conditions != 0 {
+			t.Fatalf("%s: wanted 2 with no conditions, got %s (%s)",
+				mode, s, z.Context.Conditions)
+		}
+		if err := z.Context.Err(); err != nil {
+			t.Fatalf("%s: unexpected error: %v", mode, err)
+		}
+	}
+
+	ctx.RoundingMode = Unnecessary
+	z := ctx.RoundToIntegralValue(New(25, 1))
+	if !z.IsNaN(0) || z.Context.Conditions != InvalidOperation {
+		t.Fatalf("Unnecessary: wanted NaN (invalid operation), got %s (%s)",
+			z, z.Context.Conditions)
+	}
+}
+
+func TestSetDefaultContext(t *testing.T) {
+	func() {
+		defer SetDefaultContext(Context{
+			Precision:     34,
+			RoundingMode:  ToZero,
+			OperatingMode: Go,
+			Traps:         DivisionByZero,
+			Conditions:    Inexact, // ignored
+		})()
+
+		// A zero-valued Big only uses the default precision.
+		var z Big
+		z.Quo(New(2, 0), New(3, 0))
+		if s, want := z.String(), "0.6666666666666666666666666666666667"; s != want {
+			t.Fatalf("zero Big: wanted %q, got %q", want, s)
+		}
+		z.Quo(New(1, 0), New(0, 0))
+		if err := z.Context.Err(); err != nil || !z.IsInf(+1) {
+			t.Fatalf("zero Big: wanted +Inf and no trap, got %s and %v", &z, err)
+		}
+		if z.Context.Precision != 0 || z.Context.RoundingMode != ToNearestEven ||
+			z.Context.OperatingMode != GDA || z.Context.Traps != 0 {
+			t.Fatalf("zero Big: Context was replaced: %+v", z.Context)
+		}
+		z.Context.Conditions = 0
+		x := New(1, 0)
+		if x.Context.RoundingMode != ToZero || x.Context.Conditions != 0 {
+			t.Fatalf("New: wrong Context: %+v", x.Context)
+		}
+		x.Quo(x, New(3, 0))
+		if s, want := x.String(), "0.3333333333333333333333333333333333"; s != want {
+			t.Fatalf("New: wanted %q, got %q", want, s)
+		}
+		y := new(Big)
+		y.Context.Conditions = Clamped
+		y.SetString("2")
+		if y.Context.RoundingMode != ToZero || y.Context.Conditions != Clamped {
+			t.Fatalf("SetString: wrong Context: %+v", y.Context)
+		}
+		y = WithContext(Context{Precision: 5})
+		y.SetString("2")
+		if y.Context.RoundingMode != ToNearestEven {
+			t.Fatal("SetString: replaced a non-zero Context")
+		}
+		if p := WithPrecision(0).Context.Precision; p != 34 {
+			t.Fatalf("WithPrecision(0): wanted 34, got %d", p)
+		}
+	}()
+
+	if c := DefaultContext(); !c.isZero() {
+		t.Fatalf("restore: wanted the zero Context, got %+v", c)
+	}
+	var z Big
+	z.Quo(New(2, 0), New(3, 0))
+	if s, want := z.String(), "0.6666666666666667"; s != want {
+		t.Fatalf("restored: wanted %q, got %q", want, s)
+	}
+}
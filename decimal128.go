@@ -0,0 +1,128 @@
+package decimal
+
+import "fmt"
+
+// Decimal128 is an IEEE 754-2008 decimal128: a 34-digit coefficient and an
+// exponent in [-6176, 6111].
+//
+// Unlike Big, a Decimal128 is a 16-byte value and its arithmetic never
+// allocates. Every operation behaves as if performed under Context128 with Clamp
+// set and the ToNearestEven rounding mode, and returns the Conditions it raised
+// instead of recording them. NaN results carry the same payloads as Big's.
+//
+// Decimal128 values convert to and from *Big with SetDecimal128 and
+// Big.Decimal128. The former is always exact, so the math and misc packages
+// can be used on Decimal128 values by way of a Big.
+//
+// The zero value is +0 with an exponent of zero.
+type Decimal128 struct {
+	// bits is the BID encoding XORed with the encoding of the zero value, so
+	// that the Go zero value is 0 instead of 0E-6176.
+	bits word
+}
+
+var zero128 = ieee128.bid(ieeeDatum{})
+
+func newDecimal128(d ieeeDatum, cond Condition) (Decimal128, Condition) {
+	return Decimal128{bits: ieee128.bid(d).xor(zero128)}, cond
+}
+
+func (x Decimal128) datum() ieeeDatum {
+	return ieee128.canonical(ieee128.unbid(x.bits.xor(zero128)))
+}
+
+// Decimal128 returns x rounded to a Decimal128 using x's RoundingMode. It
+// returns the same Conditions as BID128.
+func (x *Big) Decimal128() (Decimal128, Condition) {
+	return newDecimal128(ieee128.datum(x))
+}
+
+// SetDecimal128 sets z to x and returns z. z's Context is not modified and no
+// Conditions are raised.
+func (z *Big) SetDecimal128(x Decimal128) *Big { return ieee128.set(z, x.datum()) }
+
+// Abs returns |x|. Like Neg, it only modifies the sign and never raises any
+// Conditions.
+func (x Decimal128) Abs() Decimal128 { return Decimal128{bits: word{hi: x.bits.hi &^ (1 << 63), lo: x.bits.lo}} }
+
+// Add returns x + y and the Conditions raised.
+func (x Decimal128) Add(y Decimal128) (Decimal128, Condition) {
+	return newDecimal128(ieee128.add(x.datum(), y.datum(), false))
+}
+
+// Cmp compares x and y and returns:
+//
+//   -1 if x <  y
+//    0 if x == y
+//   +1 if x >  y
+//
+// The result is undefined if either x or y are NaN.
+func (x Decimal128) Cmp(y Decimal128) int { return ieee128.cmp(x.datum(), y.datum()) }
+
+// Format implements the fmt.Formatter interface. See Big.Format.
+func (x Decimal128) Format(s fmt.State, c rune) {
+	var z Big
+	z.SetDecimal128(x).Format(s, c)
+}
+
+// IsFinite returns true if x is finite.
+func (x Decimal128) IsFinite() bool { return x.datum().form&special == 0 }
+
+// IsInf returns true if x is an infinity according to sign. See Big.IsInf.
+func (x Decimal128) IsInf(sign int) bool {
+	f := x.datum().form
+	return sign >= 0 && f == pinf || sign <= 0 && f == ninf
+}
+
+// IsNaN returns true if x is NaN according to quiet. See Big.IsNaN.
+func (x Decimal128) IsNaN(quiet int) bool {
+	f := x.datum().form
+	return quiet >= 0 && f&qnan == qnan || quiet <= 0 && f&snan == snan
+}
+
+// Mul returns x * y and the Conditions raised.
+func (x Decimal128) Mul(y Decimal128) (Decimal128, Condition) {
+	return newDecimal128(ieee128.mul(x.datum(), y.datum()))
+}
+
+// Neg returns -x. Only the sign is modified, so NaN values are also negated
+// and no Conditions are raised.
+func (x Decimal128) Neg() Decimal128 { return Decimal128{bits: word{hi: x.bits.hi ^ 1<<63, lo: x.bits.lo}} }
+
+// Quo returns x / y and the Conditions raised.
+func (x Decimal128) Quo(y Decimal128) (Decimal128, Condition) {
+	return newDecimal128(ieee128.quo(x.datum(), y.datum()))
+}
+
+// Sign returns:
+//
+//   -1 if x <  0
+//    0 if x == 0
+//   +1 if x >  0
+//
+// The result is undefined if x is a NaN value.
+func (x Decimal128) Sign() int {
+	switch r := x.datum().ord(); {
+	case r < 0:
+		return -1
+	case r > 0:
+		return +1
+	default:
+		return 0
+	}
+}
+
+// Signbit returns true if x is negative, negative infinity, negative zero, or
+// negative NaN.
+func (x Decimal128) Signbit() bool { return x.bits.hi>>63 != 0 }
+
+// String returns the string representation of x. See Big.String.
+func (x Decimal128) String() string {
+	var z Big
+	return z.SetDecimal128(x).String()
+}
+
+// Sub returns x - y and the Conditions raised.
+func (x Decimal128) Sub(y Decimal128) (Decimal128, Condition) {
+	return newDecimal128(ieee128.add(x.datum(), y.datum(), true))
+}
@@ -0,0 +1,128 @@
+package decimal
+
+import "fmt"
+
+// Decimal64 is an IEEE 754-2008 decimal64: a 16-digit coefficient and an
+// exponent in [-398, 369].
+//
+// Unlike Big, a Decimal64 is an 8-byte value and its arithmetic never
+// allocates. Every operation behaves as if performed under Context64 with Clamp
+// set and the ToNearestEven rounding mode, and returns the Conditions it raised
+// instead of recording them. NaN results carry the same payloads as Big's.
+//
+// Decimal64 values convert to and from *Big with SetDecimal64 and Big.Decimal64.
+// The former is always exact, so the math and misc packages can be used on
+// Decimal64 values by way of a Big.
+//
+// The zero value is +0 with an exponent of zero.
+type Decimal64 struct {
+	// bits is the BID encoding XORed with the encoding of the zero value, so
+	// that the Go zero value is 0 instead of 0E-398.
+	bits uint64
+}
+
+var zero64 = ieee64.bid(ieeeDatum{}).lo
+
+func newDecimal64(d ieeeDatum, cond Condition) (Decimal64, Condition) {
+	return Decimal64{bits: ieee64.bid(d).lo ^ zero64}, cond
+}
+
+func (x Decimal64) datum() ieeeDatum {
+	return ieee64.canonical(ieee64.unbid(word{lo: x.bits ^ zero64}))
+}
+
+// Decimal64 returns x rounded to a Decimal64 using x's RoundingMode. It
+// returns the same Conditions as BID64.
+func (x *Big) Decimal64() (Decimal64, Condition) {
+	return newDecimal64(ieee64.datum(x))
+}
+
+// SetDecimal64 sets z to x and returns z. z's Context is not modified and no
+// Conditions are raised.
+func (z *Big) SetDecimal64(x Decimal64) *Big { return ieee64.set(z, x.datum()) }
+
+// Abs returns |x|. Like Neg, it only modifies the sign and never raises any
+// Conditions.
+func (x Decimal64) Abs() Decimal64 { return Decimal64{bits: x.bits &^ (1 << 63)} }
+
+// Add returns x + y and the Conditions raised.
+func (x Decimal64) Add(y Decimal64) (Decimal64, Condition) {
+	return newDecimal64(ieee64.add(x.datum(), y.datum(), false))
+}
+
+// Cmp compares x and y and returns:
+//
+//   -1 if x <  y
+//    0 if x == y
+//   +1 if x >  y
+//
+// The result is undefined if either x or y are NaN.
+func (x Decimal64) Cmp(y Decimal64) int { return ieee64.cmp(x.datum(), y.datum()) }
+
+// Format implements the fmt.Formatter interface. See Big.Format.
+func (x Decimal64) Format(s fmt.State, c rune) {
+	var z Big
+	z.SetDecimal64(x).Format(s, c)
+}
+
+// IsFinite returns true if x is finite.
+func (x Decimal64) IsFinite() bool { return x.datum().form&special == 0 }
+
+// IsInf returns true if x is an infinity according to sign. See Big.IsInf.
+func (x Decimal64) IsInf(sign int) bool {
+	f := x.datum().form
+	return sign >= 0 && f == pinf || sign <= 0 && f == ninf
+}
+
+// IsNaN returns true if x is NaN according to quiet. See Big.IsNaN.
+func (x Decimal64) IsNaN(quiet int) bool {
+	f := x.datum().form
+	return quiet >= 0 && f&qnan == qnan || quiet <= 0 && f&snan == snan
+}
+
+// Mul returns x * y and the Conditions raised.
+func (x Decimal64) Mul(y Decimal64) (Decimal64, Condition) {
+	return newDecimal64(ieee64.mul(x.datum(), y.datum()))
+}
+
+// Neg returns -x. Only the sign is modified, so NaN values are also negated
+// and no Conditions are raised.
+func (x Decimal64) Neg() Decimal64 { return Decimal64{bits: x.bits ^ 1<<63} }
+
+// Quo returns x / y and the Conditions raised.
+func (x Decimal64) Quo(y Decimal64) (Decimal64, Condition) {
+	return newDecimal64(ieee64.quo(x.datum(), y.datum()))
+}
+
+// Sign returns:
+//
+//   -1 if x <  0
+//    0 if x == 0
+//   +1 if x >  0
+//
+// The result is undefined if x is a NaN value.
+func (x Decimal64) Sign() int {
+	switch r := x.datum().ord(); {
+	case r < 0:
+		return -1
+	case r > 0:
+		return +1
+	default:
+		return 0
+	}
+}
+
+// Signbit returns true if x is negative, negative infinity, negative zero, or
+// negative NaN.
+func (x Decimal64) Signbit() bool { return x.bits>>63 != 0 }
+
+// String returns the string representation of x. See Big.String.
+func (x Decimal64) String() string {
+	var z Big
+	return z.SetDecimal64(x).String()
+}
+
+// Sub returns x - y and the Conditions raised.
+func (x Decimal64) Sub(y Decimal64) (Decimal64, Condition) {
+	return newDecimal64(ieee64.add(x.datum(), y.datum(), true))
+}
@@ -0,0 +1,187 @@
+package decimal_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// randIEEE returns a random string that's usually representable in a format
+// with precision p and exponents in [emin, emax], including special values.
+func randIEEE(r *rand.Rand, p, emin, emax int) string {
+	switch r.Intn(20) {
+	case 0:
+		return "0"
+	case 1:
+		return "-Inf"
+	case 2:
+		return "Inf"
+	case 3:
+		return "NaN"
+	case 4:
+		return "-sNaN"
+	}
+	b := make([]byte, 1+r.Intn(p))
+	for i := range b {
+		b[i] = byte('0' + r.Intn(10))
+	}
+	var exp int
+	switch r.Intn(3) {
+	case 0:
+		exp = emin + r.Intn(emax-emin+1)
+	case 1:
+		exp = r.Intn(2*p) - p
+	default:
+		exp = r.Intn(5) - 2
+	}
+	sign := ""
+	if r.Intn(2) == 0 {
+		sign = "-"
+	}
+	return fmt.Sprintf("%s%sE%+d", sign, b, exp)
+}
+
+func TestDecimal64(t *testing.T) {
+	testIEEE(t, decimal.Context64, func(x, y, z *decimal.Big, op string) (decimal.Condition, bool) {
+		a, _ := x.Decimal64()
+		b, _ := y.Decimal64()
+		var (
+			c    decimal.Decimal64
+			cond decimal.Condition
+		)
+		switch op {
+		case "+":
+			c, cond = a.Add(b)
+		case "-":
+			c, cond = a.Sub(b)
+		case "*":
+			c, cond = a.Mul(b)
+		case "/":
+			c, cond = a.Quo(b)
+		case "cmp":
+			return 0, a.Cmp(b) == x.Cmp(y)
+		}
+		z.SetDecimal64(c)
+		return cond, true
+	})
+}
+
+func TestDecimal128(t *testing.T) {
+	testIEEE(t, decimal.Context128, func(x, y, z *decimal.Big, op string) (decimal.Condition, bool) {
+		a, _ := x.Decimal128()
+		b, _ := y.Decimal128()
+		var (
+			c    decimal.Decimal128
+			cond decimal.Condition
+		)
+		switch op {
+		case "+":
+			c, cond = a.Add(b)
+		case "-":
+			c, cond = a.Sub(b)
+		case "*":
+			c, cond = a.Mul(b)
+		case "/":
+			c, cond = a.Quo(b)
+		case "cmp":
+			return 0, a.Cmp(b) == x.Cmp(y)
+		}
+		z.SetDecimal128(c)
+		return cond, true
+	})
+}
+
+// testIEEE checks that fn, which performs op on x and y using a fixed-size
+// type and stores the result in z, agrees with Big under ctx.
+func testIEEE(t *testing.T, ctx decimal.Context, fn func(x, y, z *decimal.Big, op string) (decimal.Condition, bool)) {
+	ctx.Traps = 0
+	ctx.Clamp = true
+	r := rand.New(rand.NewSource(1))
+	n := 20000
+	if testing.Short() {
+		n = 2000
+	}
+	emin, emax := ctx.MinScale()-ctx.Precision+1, ctx.MaxScale()-ctx.Precision+1
+	for i := 0; i < n; i++ {
+		x := decimal.WithContext(ctx)
+		y := decimal.WithContext(ctx)
+		x.SetString(randIEEE(r, ctx.Precision, emin, emax))
+		y.SetString(randIEEE(r, ctx.Precision, emin, emax))
+		ctx.Set(x, x)
+		ctx.Set(y, y)
+		x.Context.Conditions = 0
+		y.Context.Conditions = 0
+
+		for _, op := range [...]string{"+", "-", "*", "/", "cmp"} {
+			want := decimal.WithContext(ctx)
+			switch op {
+			case "+":
+				ctx.Add(want, x, y)
+			case "-":
+				ctx.Sub(want, x, y)
+			case "*":
+				ctx.Mul(want, x, y)
+			case "/":
+				ctx.Quo(want, x, y)
+			}
+
+			var got decimal.Big
+			cond, ok := fn(x, y, &got, op)
+			if op == "cmp" {
+				if !ok && !x.IsNaN(0) && !y.IsNaN(0) {
+					t.Fatalf("#%d: %s cmp %s: mismatch", i, x, y)
+				}
+				continue
+			}
+
+			wcond := want.Context.Conditions
+			if got.String() != want.String() || cond != wcond {
+				t.Fatalf(`#%d: %s %s %s
+wanted: %s (%s)
+got   : %s (%s)
+`, i, x, op, y, want, wcond, &got, cond)
+			}
+		}
+	}
+}
+
+func TestDecimal64_ZeroValue(t *testing.T) {
+	var x decimal.Decimal64
+	if s := x.String(); s != "0" {
+		t.Fatalf("wanted 0, got %s", s)
+	}
+	y, _ := decimal.New(150, 2).Decimal64()
+	if z, _ := x.Add(y); z.String() != "1.50" {
+		t.Fatalf("wanted 1.50, got %s", z)
+	}
+}
+
+func TestDecimal64_Allocs(t *testing.T) {
+	x, _ := decimal.New(12345, 2).Decimal64()
+	y, _ := decimal.New(-678, 4).Decimal64()
+	n := testing.AllocsPerRun(100, func() {
+		z, _ := x.Add(y)
+		z, _ = z.Mul(x)
+		z, _ = z.Quo(y)
+		z.Cmp(x)
+	})
+	if n != 0 {
+		t.Fatalf("wanted 0 allocations, got %v", n)
+	}
+}
+
+func TestDecimal128_Allocs(t *testing.T) {
+	x, _ := decimal.New(12345, 2).Decimal128()
+	y, _ := decimal.New(-678, 4).Decimal128()
+	n := testing.AllocsPerRun(100, func() {
+		z, _ := x.Add(y)
+		z, _ = z.Mul(x)
+		z, _ = z.Quo(y)
+		z.Cmp(x)
+	})
+	if n != 0 {
+		t.Fatalf("wanted 0 allocations, got %v", n)
+	}
+}
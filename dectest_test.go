@@ -0,0 +1,159 @@
+package decimal_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/ericlagergren/decimal/internal/test"
+	"github.com/ericlagergren/decimal/suite"
+)
+
+// decTestCases are a handful of cases from the General Decimal Arithmetic
+// .decTest files.
+const decTestCases = `
+-- A few cases from add.decTest and friends.
+version: 2.59
+extended:    1
+precision:   9
+rounding:    half_up
+maxExponent: 384
+minexponent: -383
+
+addx001 add 1       1       ->  2
+addx006 add '5.75' '3.3'    ->  9.05
+addx011 add 12345678 1.0001 -> 12345679.0 Inexact Rounded
+addx061 add -0 0            ->  0
+subx001 subtract 2 1        ->  1
+mulx001 multiply 2 3        ->  6
+divx001 divide 1 3          ->  0.333333333 Inexact Rounded
+divx010 divide 1 0          ->  Infinity Division_by_zero
+remx001 remainder 10 3      ->  1
+rmnx001 remaindernear 10 6  -> -2
+rmnx002 remaindernear 3.6 1.3 -> -0.3
+cmpx001 compare 1 2         -> -1
+cmpx002 compare NaN 1       ->  NaN
+cmsx001 comparesig NaN 1    ->  NaN Invalid_operation
+absx001 abs -2.50           ->  2.50
+intx001 tointegral 2.5      ->  3
+intx002 tointegralx 2.5     ->  3 Inexact Rounded
+lgbx001 logb 250            ->  2
+sclx001 scaleb 7.50 -2      ->  0.0750
+nxtx001 nexttoward 1 2      ->  1.00000001
+rotx001 rotate 34 8         ->  400000003
+andx001 and 1100 1010       ->  1000
+clax001 class -0            ->  -Zero
+samx001 samequantum 2.17 0.01 -> 1
+
+rounding: half_even
+basx001 tosci 1.23456789012 ->  1.23456789 Inexact Rounded
+basx002 apply 'a'           ->  NaN Conversion_syntax
+quax001 quantize 217 1e-1   ->  217.0
+
+rounding: half_down
+rhdx001 apply 123456789.5   ->  123456789 Inexact Rounded
+rhdx002 apply 123456788.51  ->  123456789 Inexact Rounded
+rhdx003 divide -1 8E+8      -> -1.25E-9
+rhdx004 divide 1 16         ->  0.0625
+
+rounding: 05up
+r05x001 apply 123456780.9   ->  123456781 Inexact Rounded
+r05x002 apply 123456781.9   ->  123456781 Inexact Rounded
+r05x003 apply -123456785.1  -> -123456786 Inexact Rounded
+r05x004 divide 2 3          ->  0.666666666 Inexact Rounded
+r05x005 divide 1 3E+8       ->  3.33333333E-9 Inexact Rounded
+r05x006 divide 1 6          ->  0.166666666 Inexact Rounded
+r05x008 divide 5 -9E+8      -> -5.55555556E-9 Inexact Rounded
+
+-- NaN payloads propagate.
+nanx001 add NaN1 -Inf       ->  NaN1
+nanx002 add -NaN2 1000      -> -NaN2
+nanx003 add sNaN11 NaN3     ->  NaN11 Invalid_operation
+nanx004 add -NaN12 sNaN4    ->  NaN4 Invalid_operation
+nanx005 multiply NaN9 -Inf  ->  NaN9
+nanx006 divide -NaN21 sNaN22 -> NaN22 Invalid_operation
+nanx007 abs -sNaN15         -> -NaN15 Invalid_operation
+nanx008 tosci NaN0123       ->  NaN123
+nanx009 tosci -sNaN99       -> -sNaN99
+nanx010 tosci NaN12x        ->  NaN Conversion_syntax
+
+-- Engineering notation.
+rounding: half_even
+engx001 toeng 1.25E+4       ->  12.5E+3
+engx002 toeng 1E+4          ->  10E+3
+engx003 toeng 123E+3        ->  123E+3
+engx004 toeng -1.23E+5      -> -123E+3
+engx005 toeng 1E+2          ->  100
+engx006 toeng 1.2E+1        ->  12
+engx007 toeng 0.000001234   ->  0.000001234
+engx008 toeng 1.234E-7      ->  123.4E-9
+engx009 toeng 1E-7          ->  100E-9
+engx010 toeng 1.2345E-8     ->  12.345E-9
+engx011 toeng 1.23456789012E+10 -> 12.3456789E+9 Inexact Rounded
+engx012 toeng 0E+1          ->  0.00E+3
+engx013 toeng 0E+2          ->  0.0E+3
+engx014 toeng 0E+3          ->  0E+3
+engx015 toeng -0E+4         -> -0.00E+6
+engx016 toeng 0E-7          ->  0.0E-6
+engx017 toeng 0E-8          ->  0.00E-6
+engx018 toeng 0E-9          ->  0E-9
+engx019 toeng 0.00          ->  0.00
+engx020 toeng -Inf          -> -Infinity
+engx021 toeng sNaN7         ->  sNaN7
+
+-- Folding down exponents, as in the IEEE decimal32 format.
+rounding:    half_even
+precision:   7
+maxExponent: 96
+minexponent: -95
+clamp:       1
+clmx001 apply 1E+90         ->  1E+90
+clmx002 apply 1E+91         ->  1.0E+91 Clamped
+clmx003 apply 1E+96         ->  1.000000E+96 Clamped
+clmx004 apply 123E+88       ->  1.23E+90
+clmx005 apply 0E+96         ->  0E+90 Clamped
+clmx006 apply 0E+100        ->  0E+90 Clamped
+clmx007 apply 1E+97         ->  Infinity Overflow Inexact Rounded
+clmx008 multiply 1E+95 1    ->  1.00000E+95 Clamped
+clmx009 add 9.999999E+96 0E+90 -> 9.999999E+96
+clmx010 multiply 1E+95 -1   -> -1.00000E+95 Clamped
+`
+
+func TestDecTest(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("_testdata", "*.decTest"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := make(test.DecMatrix)
+	fail := func(c suite.DecCase, got string, cond decimal.Condition) {
+		t.Errorf(`%s
+wanted: %s (%s)
+got   : %s (%s)
+`, c.ShortString(22), c.Output, c.Excep, got, cond)
+	}
+	cases, err := suite.ParseDecTest(strings.NewReader(decTestCases))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Run(cases, fail)
+
+	// The full .decTest files aren't included in the repository (use
+	// `go run suite/getdectest.go` to download them) and decimal.Big doesn't
+	// conform to all of them, so only report how it fared.
+	for _, name := range files {
+		file, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cases, err := suite.ParseDecTest(file)
+		file.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		m.Run(cases, nil)
+	}
+	t.Logf("\n%s", m)
+}
@@ -0,0 +1,227 @@
+package decimal
+
+// DPD32 returns x encoded as an IEEE 754-2008 decimal32 in the Densely Packed
+// Decimal format, most significant byte first. Apart from the encoding, it
+// behaves the same as BID32.
+func (x *Big) DPD32() (b [4]byte, cond Condition) {
+	cond = ieee32.encodeDPD(b[:], x)
+	return b, cond
+}
+
+// DPD64 is like DPD32, but encodes x as an IEEE 754-2008 decimal64 under
+// Context64.
+func (x *Big) DPD64() (b [8]byte, cond Condition) {
+	cond = ieee64.encodeDPD(b[:], x)
+	return b, cond
+}
+
+// DPD128 is like DPD32, but encodes x as an IEEE 754-2008 decimal128 under
+// Context128.
+func (x *Big) DPD128() (b [16]byte, cond Condition) {
+	cond = ieee128.encodeDPD(b[:], x)
+	return b, cond
+}
+
+// SetDPD32 sets z to the IEEE 754-2008 decimal32 in the Densely Packed Decimal
+// format stored in b, most significant byte first, and returns z.
+//
+// Each of the 24 non-canonical declets is decoded to the same value as its
+// canonical counterpart. The sign, quantum, and signaling bit are always
+// preserved. z's Context is not modified and no Conditions are raised.
+func (z *Big) SetDPD32(b [4]byte) *Big { return ieee32.decodeDPD(z, b[:]) }
+
+// SetDPD64 is like SetDPD32, but decodes an IEEE 754-2008 decimal64.
+func (z *Big) SetDPD64(b [8]byte) *Big { return ieee64.decodeDPD(z, b[:]) }
+
+// SetDPD128 is like SetDPD32, but decodes an IEEE 754-2008 decimal128. NaN
+// payloads that do not fit into a Payload are discarded.
+func (z *Big) SetDPD128(b [16]byte) *Big { return ieee128.decodeDPD(z, b[:]) }
+
+func (f ieeeFormat) encodeDPD(b []byte, x *Big) Condition {
+	d, cond := f.datum(x)
+	f.dpd(d).bytes(b)
+	return cond
+}
+
+func (f ieeeFormat) decodeDPD(z *Big, b []byte) *Big {
+	return f.set(z, f.undpd(wordOf(b)))
+}
+
+// dpd packs d into the Densely Packed Decimal encoding.
+func (f ieeeFormat) dpd(d ieeeDatum) (v word) {
+	t := f.t()
+	switch {
+	case d.form&nan != 0:
+		decs, _ := packDeclets(d.coeff, t/10)
+		v = word{lo: combNaN}.lsh(f.k - 6).or(decs)
+		if d.form&snan != 0 {
+			v = v.or(word{lo: 1}.lsh(f.k - 7))
+		}
+	case d.form&inf != 0:
+		v = word{lo: combInf}.lsh(f.k - 6)
+	default:
+		// The coefficient has exactly p = 3*(t/10) + 1 digits: t/10 declets
+		// and a leading digit that's stored in the combination field.
+		decs, lead := packDeclets(d.coeff, t/10)
+		e := uint64(d.exp + f.bias)
+		var g uint64
+		if lead.lo < 8 {
+			// ee ddd
+			g = (e>>f.w)<<3 | lead.lo
+		} else {
+			// 11 ee d
+			g = 3<<3 | (e>>f.w)<<1 | lead.lo&1
+		}
+		v = word{lo: g}.lsh(f.k - 6).
+			or(word{lo: e & (1<<f.w - 1)}.lsh(t)).
+			or(decs)
+	}
+	if d.form&signbit != 0 {
+		v = v.or(word{lo: 1}.lsh(f.k - 1))
+	}
+	return v
+}
+
+// undpd unpacks the Densely Packed Decimal encoding v.
+func (f ieeeFormat) undpd(v word) (d ieeeDatum) {
+	t := f.t()
+	if v.bit(f.k-1) != 0 {
+		d.form = signbit
+	}
+	g := v.rsh(f.k-6).lo & 0x1f
+	switch g {
+	case combNaN:
+		if v.bit(f.k-7) != 0 {
+			d.form |= snan
+		} else {
+			d.form |= qnan
+		}
+		d.coeff = unpackDeclets(word{}, v, t/10)
+		return d
+	case combInf:
+		d.form |= inf
+		return d
+	}
+
+	var msb, lead uint64
+	if g>>3 == 3 {
+		msb, lead = g>>1&3, 8|g&1
+	} else {
+		msb, lead = g>>3, g&7
+	}
+	e := msb<<f.w | v.rsh(t).mask(f.w).lo
+	d.exp = int(e) - f.bias
+	d.coeff = unpackDeclets(word{lo: lead}, v, t/10)
+	return d
+}
+
+// packDeclets returns the n least significant groups of three digits in x as
+// declets, the least significant in the lowest 10 bits, along with the
+// remaining digits of x.
+func packDeclets(x word, n uint) (v, rest word) {
+	for i := uint(0); i < n; i++ {
+		var r uint64
+		x, r = x.quoRem64(1000)
+		v = v.or(word{lo: uint64(bin2dpd[r])}.lsh(10 * i))
+	}
+	return v, x
+}
+
+// unpackDeclets returns lead followed by the digits of the n least significant
+// declets in v.
+func unpackDeclets(lead, v word, n uint) word {
+	x := lead
+	for i := n; i > 0; i-- {
+		dec := v.rsh(10*(i-1)).lo & 0x3ff
+		x = x.mul64(1000).add64(uint64(dpd2bin[dec]))
+	}
+	return x
+}
+
+var (
+	bin2dpd [1000]uint16 // three digits to their canonical declet
+	dpd2bin [1024]uint16 // any declet, canonical or not, to three digits
+)
+
+func init() {
+	for i := range bin2dpd {
+		bin2dpd[i] = encodeDeclet(uint16(i))
+	}
+	for i := range dpd2bin {
+		dpd2bin[i] = decodeDeclet(uint16(i))
+	}
+}
+
+// encodeDeclet packs the three digits of x, 0 <= x <= 999, into a declet.
+//
+// Writing the digits' bits as abcd efgh ijkm and the declet's as pqr stu v wxy,
+//
+//	aei   pqr stu v wxy
+//	000   bcd fgh 0 jkm
+//	001   bcd fgh 1 00m
+//	010   bcd jkh 1 01m
+//	011   bcd 10h 1 11m
+//	100   jkd fgh 1 10m
+//	101   fgd 01h 1 11m
+//	110   jkd 00h 1 11m
+//	111   00d 11h 1 11m
+func encodeDeclet(x uint16) uint16 {
+	d1, d2, d3 := x/100, x/10%10, x%10
+	var (
+		a, bcd = d1 >> 3, d1 & 7
+		e, fgh = d2 >> 3, d2 & 7
+		i, jkm = d3 >> 3, d3 & 7
+		d, h   = d1 & 1, d2 & 1
+		jk, fg = jkm >> 1, fgh >> 1
+		m      = d3 & 1
+	)
+	switch a<<2 | e<<1 | i {
+	case 0:
+		return bcd<<7 | fgh<<4 | jkm
+	case 1:
+		return bcd<<7 | fgh<<4 | 1<<3 | m
+	case 2:
+		return bcd<<7 | jk<<5 | h<<4 | 1<<3 | 1<<1 | m
+	case 3:
+		return bcd<<7 | 2<<5 | h<<4 | 7<<1 | m
+	case 4:
+		return jk<<8 | d<<7 | fgh<<4 | 1<<3 | 2<<1 | m
+	case 5:
+		return fg<<8 | d<<7 | 1<<5 | h<<4 | 7<<1 | m
+	case 6:
+		return jk<<8 | d<<7 | h<<4 | 7<<1 | m
+	default:
+		return d<<7 | 3<<5 | h<<4 | 7<<1 | m
+	}
+}
+
+// decodeDeclet returns the three digits stored in the declet x. The 24
+// non-canonical declets (those with vwx = 111, st = 11, and pq != 00) decode
+// to the same digits as their canonical forms.
+func decodeDeclet(x uint16) uint16 {
+	var (
+		pqr, pq, r = x >> 7, x >> 8, x >> 7 & 1
+		stu, st, u = x >> 4 & 7, x >> 5 & 3, x >> 4 & 1
+		wxy, y     = x & 7, x & 1
+		d1, d2, d3 uint16
+	)
+	switch {
+	case x>>3&1 == 0:
+		d1, d2, d3 = pqr, stu, wxy
+	case wxy>>1 == 0:
+		d1, d2, d3 = pqr, stu, 8|y
+	case wxy>>1 == 1:
+		d1, d2, d3 = pqr, 8|u, st<<1|y
+	case wxy>>1 == 2:
+		d1, d2, d3 = 8|r, stu, pq<<1|y
+	case st == 0:
+		d1, d2, d3 = 8|r, 8|u, pq<<1|y
+	case st == 1:
+		d1, d2, d3 = 8|r, pq<<1|u, 8|y
+	case st == 2:
+		d1, d2, d3 = pqr, 8|u, 8|y
+	default:
+		d1, d2, d3 = 8|r, 8|u, 8|y
+	}
+	return d1*100 + d2*10 + d3
+}
@@ -0,0 +1,231 @@
+package decimal
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ericlagergren/decimal/suite"
+)
+
+func TestBig_DPD(t *testing.T) {
+	for i, test := range [...]struct {
+		in   string
+		size int
+		enc  string
+		out  string
+		cond Condition
+	}{
+		0:  {"1", 4, "22500001", "1", 0},
+		1:  {"1", 8, "2238000000000001", "1", 0},
+		2:  {"1", 16, "22080000000000000000000000000001", "1", 0},
+		3:  {"-7.50", 8, "a2300000000003d0", "-7.50", 0},
+		4:  {"9999999999999999E+369", 8, "77fcff3fcff3fcff", "9.999999999999999E+384", 0},
+		5:  {"Infinity", 8, "7800000000000000", "Infinity", 0},
+		6:  {"-Infinity", 4, "f8000000", "-Infinity", 0},
+		7:  {"NaN", 8, "7c00000000000000", "NaN", 0},
+		8:  {"-sNaN", 16, "fe000000000000000000000000000000", "-sNaN", 0},
+		9:  {"NaN123", 4, "7c0000a3", "NaN123", 0},
+		10: {"1.23456789", 4, "25f4d2e8", "1.234568", Inexact | Rounded},
+		11: {"1E+96", 4, "47f00000", "1.000000E+96", Clamped},
+		12: {"1E-101", 4, "00000001", "1E-101", Subnormal},
+		13: {"-0", 8, "a238000000000000", "-0", 0},
+		14: {"1234567890123456789012345678901234", 16, "2608134b9c1e28e56f3c127177823534", "1234567890123456789012345678901234", 0},
+	} {
+		x, ok := new(Big).SetString(test.in)
+		if !ok {
+			t.Fatalf("#%d: invalid input %q", i, test.in)
+		}
+
+		var (
+			b    []byte
+			cond Condition
+			z    Big
+		)
+		switch test.size {
+		case 4:
+			a, c := x.DPD32()
+			b, cond = a[:], c
+			z.SetDPD32(a)
+		case 8:
+			a, c := x.DPD64()
+			b, cond = a[:], c
+			z.SetDPD64(a)
+		case 16:
+			a, c := x.DPD128()
+			b, cond = a[:], c
+			z.SetDPD128(a)
+		}
+		if got := hex.EncodeToString(b); got != test.enc {
+			t.Fatalf("#%d: DPD(%q): wanted %s, got %s", i, test.in, test.enc, got)
+		}
+		if cond != test.cond {
+			t.Fatalf("#%d: DPD(%q): wanted %q, got %q", i, test.in, test.cond, cond)
+		}
+		if got := z.String(); got != test.out {
+			t.Fatalf("#%d: SetDPD(%s): wanted %q, got %q", i, test.enc, test.out, got)
+		}
+	}
+}
+
+func TestDeclets(t *testing.T) {
+	seen := make(map[uint16]bool)
+	for x := uint16(0); x < 1000; x++ {
+		d := bin2dpd[x]
+		if seen[d] {
+			t.Fatalf("%03d: declet %#03x is not unique", x, d)
+		}
+		seen[d] = true
+		if got := dpd2bin[d]; got != x {
+			t.Fatalf("%03d: declet %#03x decoded to %03d", x, d, got)
+		}
+	}
+
+	// The remaining 24 declets are non-canonical and must decode to the same
+	// digits as the canonical declet with pq = 00.
+	var n int
+	for d := uint16(0); d < 1024; d++ {
+		if seen[d] {
+			continue
+		}
+		n++
+		if d&0x6e != 0x6e || d>>8 == 0 {
+			t.Fatalf("%#03x: unexpected non-canonical declet", d)
+		}
+		if want := dpd2bin[d&^0x300]; dpd2bin[d] != want {
+			t.Fatalf("%#03x: wanted %03d, got %03d", d, want, dpd2bin[d])
+		}
+	}
+	if n != 24 {
+		t.Fatalf("wanted 24 non-canonical declets, got %d", n)
+	}
+}
+
+// fptestCases are a handful of IBM .fptest vectors that are checked even if
+// the full test suite hasn't been downloaded.
+const fptestCases = `
+d32+ =0 1.5 2.25 -> 3.75
+d32* =0 -9999999E+90 1 -> -9999999E+90
+d32+ =0 1E-101 0 -> 1E-101
+d64+ =0 -7.50 0 -> -7.50
+d64* =0 123456789012345.6 1E+369 -> 1234567890123456E+369
+d64/ =0 1 3 -> 0.3333333333333333 x
+d64- =0 -0 +0 -> -0
+d64+ =0 +Inf 1 -> +Inf
+d128* =0 1234567890123456789012345678901234 1E-6176 -> 1234567890123456789012345678901234E-6176
+d128+ =0 -9.999999999999999999999999999999999E+6144 0 -> -9.999999999999999999999999999999999E+6144
+d128- =0 -Inf Q -> Q
+`
+
+// TestBig_DPDSuite round-trips every decimal32, decimal64, and decimal128
+// operand and result of the IBM .fptest files through the DPD and BID
+// encodings.
+func TestBig_DPDSuite(t *testing.T) {
+	cases, err := suite.ParseCases(strings.NewReader(fptestCases))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testDPDSuite(t, cases)
+
+	// The .fptest files aren't included in the repository. Use
+	// `go run suite/getcases.go` to download them.
+	files, err := filepath.Glob(filepath.Join("_testdata", "*.fptest"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Skip("no .fptest files in _testdata")
+	}
+	for _, name := range files {
+		file, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cases, err := suite.ParseCases(file)
+		file.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		testDPDSuite(t, cases)
+	}
+}
+
+func testDPDSuite(t *testing.T, cases []suite.Case) {
+	for _, c := range cases {
+		if c.Prefix != "d" {
+			continue
+		}
+		for _, s := range append(c.Inputs, c.Output) {
+			if s == suite.NoData {
+				continue
+			}
+			var x Big
+			if nan, signal := s.IsNaN(); nan {
+				x.SetNaN(signal)
+			} else if sign, ok := s.IsInf(); ok {
+				x.SetInf(sign < 0)
+			} else if _, ok := x.SetString(string(s)); !ok {
+				t.Fatalf("%s: invalid data %q", c, s)
+			}
+			testDPDRoundTrip(t, c, s, &x)
+		}
+	}
+}
+
+// testDPDRoundTrip checks that x, an operand or result of c, survives a round
+// trip through c's format. Operands that aren't in c's format, like the
+// integer operands of conversions, are ignored.
+func testDPDRoundTrip(t *testing.T, c suite.Case, s suite.Data, x *Big) {
+	var (
+		dpd, bid Big
+		cond     Condition
+		same     bool
+	)
+	switch c.Prec {
+	case 32:
+		a, ac := x.DPD32()
+		b, _ := x.BID32()
+		dpd.SetDPD32(a)
+		bid.SetBID32(b)
+		a2, _ := dpd.DPD32()
+		cond, same = ac, a2 == a
+	case 64:
+		a, ac := x.DPD64()
+		b, _ := x.BID64()
+		dpd.SetDPD64(a)
+		bid.SetBID64(b)
+		a2, _ := dpd.DPD64()
+		cond, same = ac, a2 == a
+	case 128:
+		a, ac := x.DPD128()
+		b, _ := x.BID128()
+		dpd.SetDPD128(a)
+		bid.SetBID128(b)
+		a2, _ := dpd.DPD128()
+		cond, same = ac, a2 == a
+	default:
+		return
+	}
+
+	switch cond {
+	case 0:
+		if got, want := dpd.String(), x.String(); got != want {
+			t.Fatalf("%s: %s: wanted %s, got %s", c, s, want, got)
+		}
+	case Clamped:
+		// The exponent was folded down, so only the value is the same.
+		if dpd.Cmp(x) != 0 {
+			t.Fatalf("%s: %s: wanted %s, got %s", c, s, x, &dpd)
+		}
+	default:
+		return
+	}
+	if !same {
+		t.Fatalf("%s: %s: re-encoding changed the bits", c, s)
+	}
+	if got, want := dpd.String(), bid.String(); got != want {
+		t.Fatalf("%s: %s: DPD and BID disagree: %s vs %s", c, s, got, want)
+	}
+}
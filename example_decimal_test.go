@@ -2,6 +2,7 @@ package decimal
 
 import (
 	"fmt"
+	"os"
 )
 
 func ExampleBig_Format() {
@@ -15,12 +16,26 @@ func ExampleBig_Format() {
 	print("%.1f", "12.34")
 	print("`%6.4g`", "500.44")
 	print("'%-10.f'", "-404.040")
+	print("%#s", "1.25E+4")
 	// Output:
 	// 12.34
 	// 12.3
 	// 12.3
 	// ` 500.4`
 	// '-404      '
+	// 12.5E+3
+}
+
+func ExampleBig_EngString() {
+	for _, s := range []string{"12.5E+3", "1E+4", "0.0000001", "0E+1"} {
+		x, _ := new(Big).SetString(s)
+		fmt.Printf("%-10s %s\n", x, x.EngString())
+	}
+	// Output:
+	// 1.25E+4    12.5E+3
+	// 1E+4       10E+3
+	// 1E-7       100E-9
+	// 0E+1       0.00E+3
 }
 
 func ExampleBig_Precision() {
@@ -76,3 +91,60 @@ func ExampleBig_Quantize() {
 	// C: -0.1
 	// D: -0E+5
 }
+
+func ExampleRecorder() {
+	var r Recorder
+	ctx := Context{
+		Precision:    8,
+		RoundingMode: ToNearestAway,
+		Options:      &Options{Hook: &r},
+	}
+
+	total := new(Big)
+	for _, s := range []string{"19.99", "4.50", "0.333333333"} {
+		x, _ := new(Big).SetString(s)
+		ctx.Add(total, total, x)
+	}
+	ctx.Quantize(total, 2)
+
+	r.WriteTo(os.Stdout)
+	fmt.Println(total)
+	// Output:
+	// Add(24.49, 0.333333333) = 24.823333 [8, ToNearestAway]: inexact, rounded
+	// Quantize(24.823333, 0.01) = 24.82 [8, ToNearestAway]: inexact, rounded
+	// 24.82
+}
+
+func ExampleParser() {
+	p := Parser{Underscores: true, NoSpecials: true, TrimSpace: true}
+	for _, s := range []string{" 1_000.50 ", "1,000.50", "NaN"} {
+		x, err := p.Parse(new(Big), s)
+		if err != nil {
+			e := err.(*SyntaxError)
+			fmt.Printf("%q: %s at offset %d\n", s, e.Reason, e.Offset)
+			continue
+		}
+		fmt.Printf("%q: %s\n", s, x)
+	}
+	// Output:
+	// " 1_000.50 ": 1000.50
+	// "1,000.50": unexpected ',' at offset 1
+	// "NaN": NaN not allowed at offset 0
+}
+
+func ExamplePattern() {
+	p := MustCompilePattern(`#,##0.00;(#,##0.00)`)
+	for _, s := range []string{"1234.5", "-1234.5", "0.005"} {
+		x, _ := new(Big).SetString(s)
+		fmt.Println(p.Format(x))
+	}
+	x := New(12345, 5)
+	fmt.Println(MustCompilePattern(`0.00%`).Format(x))
+	fmt.Println(MustCompilePattern(`0.000E+00`).Format(x))
+	// Output:
+	// 1,234.50
+	// (1,234.50)
+	// 0.00
+	// 12.34%
+	// 1.234E-01
+}
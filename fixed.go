@@ -0,0 +1,359 @@
+package decimal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+
+	"github.com/ericlagergren/decimal/internal/arith"
+	"github.com/ericlagergren/decimal/internal/arith/checked"
+)
+
+// MaxFixedScale is the largest scale of a Fixed.
+const MaxFixedScale = 19
+
+// Fixed is a fixed-point decimal with an int64 coefficient and a scale in the
+// range [0, MaxFixedScale]. Its value is coefficient * 10 ** -scale.
+//
+// Fixed is intended for hot paths, like order books and pricing engines,
+// where Big is too slow. A Fixed is a value, has no Context, and its
+// arithmetic never allocates. Each operation returns the Conditions it raised:
+// if the coefficient of a result would overflow an int64, the result is zero
+// and Overflow is raised, and if a result has to be rounded it's rounded using
+// the given RoundingMode and Inexact and Rounded are raised. The Stochastic
+// mode uses the default source from math/rand. Operations with a Fixed whose
+// scale is outside [0, MaxFixedScale] or with an invalid RoundingMode raise
+// InvalidOperation.
+//
+// Fixed values convert to *Big with SetFixed, which is always exact, and from
+// *Big with Big.Fixed, which fails if the value doesn't fit.
+//
+// The zero value is 0 with a scale of zero.
+type Fixed struct {
+	coeff int64
+	scale int
+}
+
+// NewFixed returns the Fixed coeff * 10 ** -scale. For example,
+// NewFixed(12345, 2) is 123.45.
+func NewFixed(coeff int64, scale int) Fixed {
+	return Fixed{coeff: coeff, scale: scale}
+}
+
+// newFixed returns the Fixed with the magnitude m, the sign neg, and the scale
+// s, or raises Overflow if m doesn't fit into an int64.
+func newFixed(m uint64, neg bool, s int) (Fixed, Condition) {
+	if neg {
+		if m > 1<<63 {
+			return Fixed{}, Overflow
+		}
+		return Fixed{coeff: -int64(m), scale: s}, 0
+	}
+	if m > math.MaxInt64 {
+		return Fixed{}, Overflow
+	}
+	return Fixed{coeff: int64(m), scale: s}, 0
+}
+
+// abs returns |x|'s coefficient and whether x is negative.
+func (x Fixed) abs() (uint64, bool) {
+	if x.coeff < 0 {
+		return uint64(-x.coeff), true
+	}
+	return uint64(x.coeff), false
+}
+
+func (x Fixed) valid() bool { return x.scale >= 0 && x.scale <= MaxFixedScale }
+
+// Coeff returns x's coefficient.
+func (x Fixed) Coeff() int64 { return x.coeff }
+
+// Scale returns x's scale.
+func (x Fixed) Scale() int { return x.scale }
+
+// Sign returns:
+//
+//   -1 if x <  0
+//    0 if x == 0
+//   +1 if x >  0
+//
+func (x Fixed) Sign() int {
+	switch {
+	case x.coeff < 0:
+		return -1
+	case x.coeff > 0:
+		return +1
+	default:
+		return 0
+	}
+}
+
+// Cmp compares x and y and returns:
+//
+//   -1 if x <  y
+//    0 if x == y
+//   +1 if x >  y
+//
+// The result is undefined if either x or y is invalid.
+func (x Fixed) Cmp(y Fixed) int {
+	xs, ys := x.Sign(), y.Sign()
+	if xs != ys {
+		return arith.Cmp(uint64(xs+1), uint64(ys+1))
+	}
+	xm, _ := x.abs()
+	ym, _ := y.abs()
+
+	// Compare |x| and |y| with the same scale, (x1, x0) and (y1, y0).
+	var x1, y1 uint64
+	x0, y0 := xm, ym
+	if x.scale < y.scale {
+		p, _ := arith.Pow10(uint64(y.scale - x.scale))
+		x1, x0 = arith.Mul128(xm, p)
+	} else {
+		p, _ := arith.Pow10(uint64(x.scale - y.scale))
+		y1, y0 = arith.Mul128(ym, p)
+	}
+	r := arith.Cmp(x1, y1)
+	if r == 0 {
+		r = arith.Cmp(x0, y0)
+	}
+	return r * xs
+}
+
+// Neg returns -x. It raises Overflow if x's coefficient is math.MinInt64.
+func (x Fixed) Neg() (Fixed, Condition) {
+	m, neg := x.abs()
+	return newFixed(m, !neg, x.scale)
+}
+
+// Add returns x + y, with the larger of their scales, and the Conditions
+// raised. The result is exact unless Overflow is raised.
+func (x Fixed) Add(y Fixed) (Fixed, Condition) { return x.add(y, false) }
+
+// Sub returns x - y, with the larger of their scales, and the Conditions
+// raised. The result is exact unless Overflow is raised.
+func (x Fixed) Sub(y Fixed) (Fixed, Condition) { return x.add(y, true) }
+
+// add is the implementation of Add and Sub.
+func (x Fixed) add(y Fixed, sub bool) (Fixed, Condition) {
+	if !x.valid() || !y.valid() {
+		return Fixed{}, InvalidOperation
+	}
+	s := x.scale
+	if y.scale > s {
+		s = y.scale
+	}
+
+	xm, xneg := x.abs()
+	ym, yneg := y.abs()
+	yneg = yneg != sub
+	xm, xok := checked.MulPow10(xm, uint64(s-x.scale))
+	ym, yok := checked.MulPow10(ym, uint64(s-y.scale))
+	if !xok || !yok {
+		return Fixed{}, Overflow
+	}
+
+	if xneg == yneg {
+		m, ok := checked.Add(xm, ym)
+		if !ok {
+			return Fixed{}, Overflow
+		}
+		return newFixed(m, xneg, s)
+	}
+	if xm >= ym {
+		return newFixed(xm-ym, xneg, s)
+	}
+	return newFixed(ym-xm, yneg, s)
+}
+
+// Mul returns x * y, with the larger of their scales and rounded using m, and
+// the Conditions raised.
+func (x Fixed) Mul(y Fixed, m RoundingMode) (Fixed, Condition) {
+	if !x.valid() || !y.valid() || m > Unnecessary {
+		return Fixed{}, InvalidOperation
+	}
+	xm, xneg := x.abs()
+	ym, yneg := y.abs()
+
+	// The product has the scale x.scale + y.scale, so remove the digits of
+	// the smaller of the two scales.
+	s, k := x.scale, y.scale
+	if k > s {
+		s, k = k, s
+	}
+	hi, lo := arith.Mul128(xm, ym)
+	d, _ := arith.Pow10(uint64(k))
+	return quoFixed(m, hi, lo, d, xneg != yneg, s)
+}
+
+// Quo returns x / y, with the larger of their scales and rounded using m, and
+// the Conditions raised. If y is zero, it raises DivisionByZero, or
+// InvalidOperation if x is zero too.
+func (x Fixed) Quo(y Fixed, m RoundingMode) (Fixed, Condition) {
+	if !x.valid() || !y.valid() || m > Unnecessary {
+		return Fixed{}, InvalidOperation
+	}
+	xm, xneg := x.abs()
+	ym, yneg := y.abs()
+	if ym == 0 {
+		if xm == 0 {
+			return Fixed{}, InvalidOperation
+		}
+		return Fixed{}, DivisionByZero
+	}
+
+	// x / y with the scale s is (xm * 10 ** (s - x.scale + y.scale)) / ym,
+	// with a shift of up to 2*MaxFixedScale digits.
+	s := x.scale
+	if y.scale > s {
+		s = y.scale
+	}
+	n := uint64(s - x.scale + y.scale)
+	var hi, lo uint64
+	if n < arith.PowTabLen {
+		p, _ := arith.Pow10(n)
+		hi, lo = arith.Mul128(xm, p)
+	} else {
+		p, _ := arith.Pow10(arith.PowTabLen - 1)
+		hi, lo = arith.Mul128(xm, p)
+		p, _ = arith.Pow10(n - (arith.PowTabLen - 1))
+		var ok bool
+		if hi, lo, ok = checked.Mul128(hi, lo, p); !ok {
+			return Fixed{}, Overflow
+		}
+	}
+	return quoFixed(m, hi, lo, ym, xneg != yneg, s)
+}
+
+// Rescale returns x with the scale n, rounded using m if n < x's scale, and
+// the Conditions raised.
+func (x Fixed) Rescale(n int, m RoundingMode) (Fixed, Condition) {
+	if !x.valid() || n < 0 || n > MaxFixedScale || m > Unnecessary {
+		return Fixed{}, InvalidOperation
+	}
+	xm, neg := x.abs()
+	if n >= x.scale {
+		xm, ok := checked.MulPow10(xm, uint64(n-x.scale))
+		if !ok {
+			return Fixed{}, Overflow
+		}
+		return newFixed(xm, neg, n)
+	}
+	d, _ := arith.Pow10(uint64(x.scale - n))
+	return quoFixed(m, 0, xm, d, neg, n)
+}
+
+// quoFixed returns the Fixed with the magnitude (hi, lo) / d rounded using m,
+// the sign neg, and the scale s, and the Conditions raised.
+func quoFixed(m RoundingMode, hi, lo, d uint64, neg bool, s int) (Fixed, Condition) {
+	if hi >= d {
+		return Fixed{}, Overflow
+	}
+	q, r := bits.Div64(hi, lo, d)
+	if r == 0 {
+		return newFixed(q, neg, s)
+	}
+
+	cond := Inexact | Rounded
+	if m == Unnecessary {
+		return Fixed{}, InvalidOperation | cond
+	}
+
+	var inc bool
+	if m == Stochastic {
+		inc = stochastic(nil, r, d)
+	} else {
+		rc := 1
+		if r2, ok := checked.Mul(r, 2); ok {
+			rc = arith.Cmp(r2, d)
+		}
+		inc = m.needsInc(q%10, rc, !neg)
+	}
+	if inc {
+		q++
+	}
+	z, c := newFixed(q, neg, s)
+	if c != 0 || (inc && q == 0) {
+		return Fixed{}, Overflow
+	}
+	return z, cond
+}
+
+// String returns the string representation of x. It's the same as that of an
+// equivalent Big.
+func (x Fixed) String() string {
+	var z Big
+	return z.SetFixed(x).String()
+}
+
+// Format implements the fmt.Formatter interface like Big.Format.
+func (x Fixed) Format(s fmt.State, c rune) {
+	var z Big
+	z.SetFixed(x).Format(s, c)
+}
+
+var (
+	_ fmt.Formatter = Fixed{}
+	_ fmt.Stringer  = Fixed{}
+)
+
+// Fixed returns x as a Fixed and a bool indicating whether x can be
+// represented exactly. Trailing zeros are removed from x's coefficient if its
+// scale is too large or if it doesn't fit into an int64, and zeros are
+// appended if its scale is negative. x's Context is ignored and no Conditions
+// are raised.
+func (x *Big) Fixed() (Fixed, bool) {
+	if !x.IsFinite() {
+		return Fixed{}, false
+	}
+
+	m, s := x.compact, -x.exp
+	if x.isInflated() {
+		// Only trailing zeros can make x fit.
+		var q, r, ten big.Int
+		q.Set(&x.unscaled)
+		ten.SetUint64(10)
+		for q.BitLen() > 64 && s > 0 {
+			if q.QuoRem(&q, &ten, &r); r.Sign() != 0 {
+				return Fixed{}, false
+			}
+			s--
+		}
+		if q.BitLen() > 64 {
+			return Fixed{}, false
+		}
+		m = q.Uint64()
+	}
+
+	if m == 0 {
+		if s < 0 {
+			s = 0
+		} else if s > MaxFixedScale {
+			s = MaxFixedScale
+		}
+		return Fixed{scale: s}, true
+	}
+	if s < 0 {
+		var ok bool
+		if m, ok = checked.MulPow10(m, uint64(-s)); !ok {
+			return Fixed{}, false
+		}
+		s = 0
+	}
+	for m%10 == 0 && s > 0 && (s > MaxFixedScale || m > math.MaxInt64) {
+		m /= 10
+		s--
+	}
+	if s > MaxFixedScale {
+		return Fixed{}, false
+	}
+	z, c := newFixed(m, x.Signbit(), s)
+	return z, c == 0
+}
+
+// SetFixed sets z to x and returns z. z's Context is not modified and no
+// Conditions are raised.
+func (z *Big) SetFixed(x Fixed) *Big {
+	return z.SetMantScale(x.coeff, x.scale)
+}
@@ -0,0 +1,196 @@
+package decimal_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestFixed(t *testing.T) {
+	const (
+		inexact = decimal.Inexact | decimal.Rounded
+		maxInt  = math.MaxInt64
+		minInt  = math.MinInt64
+	)
+	f := decimal.NewFixed
+	for i, test := range [...]struct {
+		op   string
+		x, y decimal.Fixed
+		m    decimal.RoundingMode
+		r    string
+		c    decimal.Condition
+	}{
+		0:  {"+", f(125, 2), f(3, 0), 0, "4.25", 0},
+		1:  {"-", f(15, 1), f(225, 2), 0, "-0.75", 0},
+		2:  {"+", f(maxInt, 0), f(1, 0), 0, "0", decimal.Overflow},
+		3:  {"+", f(maxInt, 0), f(1, 1), 0, "0", decimal.Overflow},
+		4:  {"-", f(minInt+1, 0), f(1, 0), 0, "-9223372036854775808", 0},
+		5:  {"-", f(minInt, 0), f(1, 0), 0, "0", decimal.Overflow},
+		6:  {"*", f(125, 2), f(15, 1), decimal.ToNearestEven, "1.88", inexact},
+		7:  {"*", f(125, 2), f(15, 1), decimal.ToZero, "1.87", inexact},
+		8:  {"*", f(125, 2), f(15, 1), decimal.Unnecessary, "0", decimal.InvalidOperation | inexact},
+		9:  {"*", f(3037000500, 0), f(3037000500, 0), 0, "0", decimal.Overflow},
+		10: {"*", f(minInt, 0), f(1, 0), 0, "-9223372036854775808", 0},
+		11: {"*", f(-maxInt, 19), f(maxInt, 19), decimal.AwayFromZero, "-0.8507059173023461585", inexact},
+		12: {"/", f(1, 0), f(300, 2), decimal.ToNearestEven, "0.33", inexact},
+		13: {"/", f(20000, 4), f(-3, 0), decimal.ToNearestAway, "-0.6667", inexact},
+		14: {"/", f(1, 0), f(0, 0), 0, "0", decimal.DivisionByZero},
+		15: {"/", f(0, 0), f(0, 3), 0, "0", decimal.InvalidOperation},
+		16: {"/", f(1, 19), f(3, 19), decimal.ToNearestEven, "0.3333333333333333333", inexact},
+		17: {"/", f(1, 0), f(1, 19), 0, "0", decimal.Overflow},
+		18: {"/", f(1, 0), f(4, 19), 0, "0", decimal.Overflow},
+		19: {"/", f(1, 1), f(5e18, 19), 0, "0.2000000000000000000", 0},
+		20: {"r", f(1255, 3), f(2, 0), decimal.ToNearestEven, "1.26", inexact},
+		21: {"r", f(1255, 3), f(2, 0), decimal.ToNearestTowardZero, "1.25", inexact},
+		22: {"r", f(-1255, 3), f(2, 0), decimal.ToPositiveInf, "-1.25", inexact},
+		23: {"r", f(1255, 3), f(5, 0), 0, "1.25500", 0},
+		24: {"r", f(maxInt, 0), f(1, 0), 0, "0", decimal.Overflow},
+		25: {"r", f(1, 20), f(2, 0), 0, "0", decimal.InvalidOperation},
+		26: {"+", f(1, -1), f(2, 0), 0, "0", decimal.InvalidOperation},
+		27: {"n", f(minInt, 0), decimal.Fixed{}, 0, "0", decimal.Overflow},
+		28: {"n", f(-15, 1), decimal.Fixed{}, 0, "1.5", 0},
+	} {
+		var (
+			z decimal.Fixed
+			c decimal.Condition
+		)
+		switch test.op {
+		case "+":
+			z, c = test.x.Add(test.y)
+		case "-":
+			z, c = test.x.Sub(test.y)
+		case "*":
+			z, c = test.x.Mul(test.y, test.m)
+		case "/":
+			z, c = test.x.Quo(test.y, test.m)
+		case "r":
+			z, c = test.x.Rescale(int(test.y.Coeff()), test.m)
+		case "n":
+			z, c = test.x.Neg()
+		}
+		if s := z.String(); s != test.r || c != test.c {
+			t.Fatalf("#%d: %s %s %s: wanted %s (%s), got %s (%s)",
+				i, test.x, test.op, test.y, test.r, test.c, s, c)
+		}
+	}
+}
+
+func TestFixed_Cmp(t *testing.T) {
+	f := decimal.NewFixed
+	for i, test := range [...]struct {
+		x, y decimal.Fixed
+		r    int
+	}{
+		{f(150, 2), f(15, 1), 0},
+		{f(-2, 0), f(1, 0), -1},
+		{f(-15, 1), f(-125, 2), -1},
+		{f(math.MaxInt64, 0), f(1, 19), +1},
+		{f(0, 3), decimal.Fixed{}, 0},
+	} {
+		if r := test.x.Cmp(test.y); r != test.r {
+			t.Fatalf("#%d: Cmp(%s, %s): wanted %d, got %d", i, test.x, test.y, test.r, r)
+		}
+		if r := test.y.Cmp(test.x); r != -test.r {
+			t.Fatalf("#%d: Cmp(%s, %s): wanted %d, got %d", i, test.y, test.x, -test.r, r)
+		}
+	}
+}
+
+func TestFixed_Big(t *testing.T) {
+	for i, test := range [...]struct {
+		x  string
+		r  string
+		ok bool
+	}{
+		{"123.45", "123.45", true},
+		{"-0.001", "-0.001", true},
+		{"1E+3", "1000", true},
+		{"1.000000000000000000000000", "1.000000000000000000", true},
+		{"-9223372036854775808", "-9223372036854775808", true},
+		{"9223372036854775808", "", false},
+		{"1E+19", "", false},
+		{"1E-20", "", false},
+		{"0E-30", "0E-19", true},
+		{"NaN", "", false},
+		{"-Inf", "", false},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		z, ok := x.Fixed()
+		if ok != test.ok {
+			t.Fatalf("#%d: Fixed(%s): wanted %t, got %t", i, test.x, test.ok, ok)
+		}
+		if !ok {
+			continue
+		}
+		if s := z.String(); s != test.r {
+			t.Fatalf("#%d: Fixed(%s): wanted %q, got %q", i, test.x, test.r, s)
+		}
+		if new(decimal.Big).SetFixed(z).Cmp(x) != 0 {
+			t.Fatalf("#%d: SetFixed(%s) != %s", i, z, x)
+		}
+	}
+}
+
+// TestFixed_Rounding checks Mul and Quo against Big.
+func TestFixed_Rounding(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		x := decimal.NewFixed(rng.Int63n(2e9)-1e9, rng.Intn(6))
+		y := decimal.NewFixed(rng.Int63n(2e6)-1e6+1, rng.Intn(6))
+		m := decimal.RoundingMode(rng.Intn(int(decimal.Stochastic)))
+
+		s := x.Scale()
+		if y.Scale() > s {
+			s = y.Scale()
+		}
+		ctx := decimal.Context{
+			Precision:    decimal.UnlimitedPrecision,
+			RoundingMode: m,
+			Options:      &decimal.Options{FixedScale: true, Scale: s},
+		}
+		var xb, yb decimal.Big
+		xb.SetFixed(x)
+		yb.SetFixed(y)
+
+		for _, op := range [...]string{"*", "/"} {
+			var (
+				z  decimal.Fixed
+				c  decimal.Condition
+				zb = decimal.WithContext(ctx)
+			)
+			if op == "*" {
+				z, c = x.Mul(y, m)
+				ctx.Mul(zb, &xb, &yb)
+			} else if y.Sign() != 0 {
+				z, c = x.Quo(y, m)
+				ctx.Quo(zb, &xb, &yb)
+			} else {
+				continue
+			}
+			want, ok := zb.Fixed()
+			if !ok {
+				t.Fatalf("#%d: %s %s %s (%s): Big result %s doesn't fit", i, x, op, y, m, zb)
+			}
+			if z.Cmp(want) != 0 || c&decimal.Inexact != zb.Context.Conditions&decimal.Inexact {
+				t.Fatalf("#%d: %s %s %s (%s): wanted %s (%s), got %s (%s)",
+					i, x, op, y, m, zb, zb.Context.Conditions, z, c)
+			}
+		}
+	}
+}
+
+func TestFixed_Allocs(t *testing.T) {
+	x := decimal.NewFixed(123456789, 4)
+	y := decimal.NewFixed(-987, 2)
+	n := testing.AllocsPerRun(100, func() {
+		z, _ := x.Mul(y, decimal.ToNearestEven)
+		z, _ = z.Quo(y, decimal.ToNearestEven)
+		z, _ = z.Add(x)
+		z.Rescale(2, decimal.ToZero)
+	})
+	if n != 0 {
+		t.Fatalf("wanted 0 allocations, got %g", n)
+	}
+}
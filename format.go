@@ -1,11 +1,11 @@
 package decimal
 
 import (
-	"bytes"
-	"fmt"
-	"io"
 	"math/big"
+	"math/rand"
 	"strconv"
+
+	"github.com/ericlagergren/decimal/internal/arith"
 )
 
 // allZeros returns true if every character in b is '0'.
@@ -20,10 +20,36 @@ func allZeros(b []byte) bool {
 
 var zero = []byte{'0'}
 
-// roundString rounds the plain numeric string (e.g., "1234") b.
-func roundString(b []byte, mode RoundingMode, pos bool, prec int) []byte {
+// cmpHalf compares the fraction 0.b to 1/2.
+func cmpHalf(b []byte) int {
+	switch {
+	case b[0] < '5':
+		return -1
+	case b[0] > '5' || !allZeros(b[1:]):
+		return +1
+	default:
+		return 0
+	}
+}
+
+// stochasticString is like stochastic, but for the fraction 0.b.
+func stochasticString(src rand.Source64, b []byte) bool {
+	// 19 digits are plenty, and fit in a uint64.
+	if len(b) > 19 {
+		b = b[:19]
+	}
+	r, _ := strconv.ParseUint(string(b), 10, 64)
+	y, _ := arith.Pow10(uint64(len(b)))
+	return stochastic(src, r, y)
+}
+
+// roundString rounds the plain numeric string (e.g., "1234") b. src is only
+// used by the Stochastic mode. Formatting never modifies a Big, so it can't
+// fail like the arithmetic does in the Unnecessary mode, which instead rounds
+// like ToNearestEven.
+func roundString(b []byte, mode RoundingMode, src rand.Source64, pos bool, prec int) []byte {
 	if prec >= len(b) {
-		return append(b, bytes.Repeat(zero, prec-len(b))...)
+		return appendZeros(b, prec-len(b))
 	}
 
 	// Trim zeros until prec. This is useful when we can round exactly by simply
@@ -32,31 +58,21 @@ func roundString(b []byte, mode RoundingMode, pos bool, prec int) []byte {
 		return b[:prec]
 	}
 
-	b = b[:prec+1]
 	i := prec - 1
+	var inc bool
+	switch mode {
+	case Stochastic:
+		inc = stochasticString(src, b[prec:])
+	case Unnecessary:
+		inc = ToNearestEven.needsInc(uint64(b[i]-'0'), cmpHalf(b[prec:]), pos)
+	default:
+		inc = mode.needsInc(uint64(b[i]-'0'), cmpHalf(b[prec:]), pos)
+	}
+	b = b[:prec+1]
 
 	// Blindly increment b[i] and handle possible carries later.
-	switch mode {
-	case AwayFromZero:
+	if inc {
 		b[i]++
-	case ToZero:
-		// OK
-	case ToPositiveInf:
-		if pos {
-			b[i]++
-		}
-	case ToNegativeInf:
-		if !pos {
-			b[i]++
-		}
-	case ToNearestEven:
-		if b[i+1] > '5' || b[i+1] == '5' && b[i]%2 != 0 {
-			b[i]++
-		}
-	case ToNearestAway:
-		if b[i+1] >= '5' {
-			b[i]++
-		}
 	}
 
 	if b[i] != '9'+1 {
@@ -87,10 +103,27 @@ func roundString(b []byte, mode RoundingMode, pos bool, prec int) []byte {
 	return b[:prec]
 }
 
-// formatCompact formats the compact decimal, x, as an unsigned integer.
-func formatCompact(x uint64) []byte {
-	var b [20]byte
-	return strconv.AppendUint(b[0:0], uint64(x), 10)
+// appendZeros appends n '0' bytes to b.
+func appendZeros(b []byte, n int) []byte {
+	// zeroLiterals is 16 '0' bytes. It's used to speed up appendZeros.
+	const zeroLiterals = "0000000000000000"
+	for ; n > len(zeroLiterals); n -= len(zeroLiterals) {
+		b = append(b, zeroLiterals...)
+	}
+	if n > 0 {
+		b = append(b, zeroLiterals[:n]...)
+	}
+	return b
+}
+
+// coeffDigits returns the digits of x's coefficient, which must be finite.
+// If x is compact, they're appended to tmp, which should be empty and have
+// room for 20 digits.
+func (x *Big) coeffDigits(tmp []byte) []byte {
+	if x.isCompact() {
+		return strconv.AppendUint(tmp, x.compact, 10)
+	}
+	return formatUnscaled(&x.unscaled)
 }
 
 // formatUnscaled formats the unscaled (non-compact) decimal, unscaled, as an
@@ -115,103 +148,117 @@ const (
 	normal format = iota // either sci or plain, depending on x
 	plain                // forced plain
 	sci                  // forced sci
+	eng                  // either eng or plain, depending on x
 )
 
 //go:generate stringer -type=format
 
+// formatter formats decimals by appending them to a []byte. Its methods take
+// and return the destination like strconv's Append functions, which lets
+// callers format into a buffer on the stack.
 type formatter struct {
-	w interface {
-		io.Writer
-		io.ByteWriter
-		WriteString(string) (int, error)
-	}
-	sign  byte  // leading '+' or ' ' flag
-	prec  int   // total precision
-	width int   // min width
-	n     int64 // cumulative number of bytes written to w
+	sign  byte // leading '+' or ' ' flag
+	prec  int  // total precision
+	width int  // min width
 }
 
-func (f *formatter) WriteByte(c byte) error {
-	f.n++
-	return f.w.WriteByte(c)
+var sciE = [2]byte{GDA: 'E', Go: 'e'}
+
+// specialString returns x, which must be special, in the GDA format. NaNs
+// include their payloads, if any, e.g. "-sNaN123".
+func (x *Big) specialString() string {
+	return string(x.appendSpecial(nil))
 }
 
-func (f *formatter) WriteString(s string) (int, error) {
-	m, err := f.w.WriteString(s)
-	f.n += int64(m)
-	return m, err
+// appendSpecial appends x, which must be special, in the GDA format to dst.
+func (x *Big) appendSpecial(dst []byte) []byte {
+	dst = append(dst, x.form.String()...)
+	if x.IsNaN(0) && x.compact != 0 {
+		dst = strconv.AppendUint(dst, x.compact, 10)
+	}
+	return dst
 }
 
-func (f *formatter) Write(p []byte) (n int, err error) {
-	n, err = f.w.Write(p)
-	f.n += int64(n)
-	return n, err
+// appendFormat appends x to dst using format, x's precision, and the exponent
+// character for x's OperatingMode.
+func (x *Big) appendFormat(dst []byte, format format) []byte {
+	f := formatter{prec: x.Precision(), width: noWidth}
+	return f.format(dst, x, format, sciE[x.Context.OperatingMode])
 }
 
-var sciE = [2]byte{GDA: 'E', Go: 'e'}
+// fracPrec returns the total precision needed to format x with n digits
+// following the radix. A negative result means x rounds to zero and needs -n
+// zeros.
+func (x *Big) fracPrec(n int) int {
+	if x.exp > 0 {
+		return n + x.Precision()
+	}
+	if adj := x.exp + x.Precision(); adj > -n {
+		return n + adj
+	}
+	return -n
+}
 
-func (f *formatter) format(x *Big, format format, e byte) {
+// format appends x to dst using format and the exponent character e.
+func (f *formatter) format(dst []byte, x *Big, format format, e byte) []byte {
 	if x == nil {
-		f.WriteString("<nil>")
-		return
+		return append(dst, "<nil>"...)
 	}
 
 	o := x.Context.OperatingMode
 	if x.isSpecial() {
 		switch o {
 		case GDA:
-			f.WriteString(x.form.String())
-			if x.IsNaN(0) && x.compact != 0 {
-				f.WriteString(strconv.FormatUint(x.compact, 10))
-			}
+			dst = x.appendSpecial(dst)
 		case Go:
 			if x.IsNaN(0) {
-				f.WriteString("NaN")
+				dst = append(dst, "NaN"...)
 			} else if x.IsInf(+1) {
-				f.WriteString("+Inf")
+				dst = append(dst, "+Inf"...)
 			} else {
-				f.WriteString("-Inf")
+				dst = append(dst, "-Inf"...)
 			}
 		}
-		return
+		return dst
 	}
 
 	if x.compact == 0 && o == Go {
 		// Go mode prints zeros different than GDA.
 		if f.width == noWidth {
-			f.WriteByte('0')
-		} else {
-			f.WriteString("0.")
-			io.CopyN(f, zeroReader{}, int64(f.width))
+			return append(dst, '0')
 		}
-		return
+		dst = append(dst, "0."...)
+		return appendZeros(dst, f.width)
 	}
 
 	neg := x.Signbit()
 	if neg {
-		f.WriteByte('-')
+		dst = append(dst, '-')
 	} else if f.sign != 0 {
-		f.WriteByte(f.sign)
+		dst = append(dst, f.sign)
 	}
 
 	var (
 		b   []byte
 		exp int
+		tmp [20]byte // compact coefficients, without allocating
 	)
 	if f.prec > 0 {
-		if x.isCompact() {
-			b = formatCompact(x.compact)
-		} else {
-			b = formatUnscaled(&x.unscaled)
-		}
+		b = x.coeffDigits(tmp[:0])
 		orig := len(b)
-		b = roundString(b, x.Context.RoundingMode, !neg, f.prec)
-		exp = int(x.exp) + orig - len(b)
+		b = roundString(b, x.Context.RoundingMode, x.Context.source(), !neg, f.prec)
+		exp = int(x.exp) + orig - f.prec
+		if len(b) > f.prec && format != plain {
+			// Rounding carried into a new digit, e.g. 99 became 10 with a
+			// precision of 1, so drop the extra zero to keep the precision.
+			b = b[:f.prec]
+			exp++
+		}
 	} else if f.prec < 0 {
 		f.prec = -f.prec
 		exp = -f.prec
 	} else {
-		b = []byte{'0'}
+		b = zero
 	}
 
 	// "Next, the adjusted exponent is calculated; this is the exponent, plus
@@ -226,66 +273,119 @@ func (f *formatter) format(x *Big, format format, e byte) {
 			// converted to a character form without using exponential notation."
 			//
 			// - http://speleotrove.com/decimal/daconvs.html#reftostr
-			f.formatPlain(b, exp)
-			return
+			return f.formatPlain(dst, b, exp)
 		}
 
 		// No decimal places, write b and fill with zeros.
 		if format == plain && exp > 0 {
-			f.Write(b)
-			io.CopyN(f, zeroReader{}, int64(exp))
-			return
+			return appendZeros(append(dst, b...), exp)
+		}
+
+		if format == eng {
+			return f.formatEng(dst, b, adj, e)
 		}
 	}
-	f.formatSci(b, adj, e)
+	return f.formatSci(dst, b, adj, e)
 }
 
-// formatSci returns the scientific version of b.
-func (f *formatter) formatSci(b []byte, adj int, e byte) {
-	f.WriteByte(b[0])
+// formatSci appends the scientific version of b to dst.
+func (f *formatter) formatSci(dst, b []byte, adj int, e byte) []byte {
+	dst = append(dst, b[0])
 
 	if len(b) > 1 {
-		f.WriteByte('.')
-		f.Write(b[1:])
+		dst = append(dst, '.')
+		dst = append(dst, b[1:]...)
 	}
+	return appendExp(dst, adj, e)
+}
 
-	// If negative, the call to strconv.Itoa will add the minus sign for us.
-	f.WriteByte(e)
+// appendExp appends the exponent adj, e.g. "E+12" or "E-3", to dst.
+func appendExp(dst []byte, adj int, e byte) []byte {
+	// If negative, strconv.AppendInt will add the minus sign for us.
+	dst = append(dst, e)
 	if adj > 0 {
-		f.WriteByte('+')
+		dst = append(dst, '+')
+	}
+	return strconv.AppendInt(dst, int64(adj), 10)
+}
+
+// formatEng appends the engineering version of b, whose exponent is a
+// multiple of three, to dst.
+//
+// "If exponential notation is used, the exponent is adjusted to be a multiple
+// of three (engineering notation) by positioning the decimal point with one,
+// two, or three characters preceding it (that is, the part before the decimal
+// point will range from 1 through 999). This may require the addition of
+// either one or two trailing zeros.
+//
+// If after the adjustment the exponent is zero, then no indicator letter and
+// exponent is suffixed. Note that the adjustment of the exponent for a zero
+// coefficient is handled differently: the exponent is increased to the next
+// multiple of three and the number of digits after the decimal point is
+// increased by the same amount."
+//
+// - http://speleotrove.com/decimal/daconvs.html#reftoeng
+func (f *formatter) formatEng(dst, b []byte, adj int, e byte) []byte {
+	m := adj % 3
+	if m < 0 {
+		m += 3
+	}
+
+	if len(b) == 1 && b[0] == '0' {
+		dst = append(dst, '0')
+		if m != 0 {
+			adj += 3 - m
+			dst = append(dst, '.')
+			dst = appendZeros(dst, 3-m)
+		}
+	} else {
+		adj -= m
+		if n := m + 1; n < len(b) {
+			dst = append(dst, b[:n]...)
+			dst = append(dst, '.')
+			dst = append(dst, b[n:]...)
+		} else {
+			dst = append(dst, b...)
+			dst = appendZeros(dst, n-len(b))
+		}
 	}
-	f.WriteString(strconv.Itoa(adj))
+
+	if adj == 0 {
+		return dst
+	}
+	return appendExp(dst, adj, e)
 }
 
-// formatPlain returns the plain string version of b.
-func (f *formatter) formatPlain(b []byte, exp int) {
+// formatPlain appends the plain string version of b to dst.
+func (f *formatter) formatPlain(dst, b []byte, exp int) []byte {
 	const zeroRadix = "0."
 
 	switch radix := len(b) + exp; {
 	// log10(b) == scale, so immediately before b: 0.123456
 	case radix == 0:
-		f.WriteString(zeroRadix)
-		f.Write(b)
+		dst = append(dst, zeroRadix...)
+		dst = append(dst, b...)
 
 	// log10(b) > scale, so somewhere inside b: 123.456
 	case radix > 0:
-		f.Write(b[:radix])
+		dst = append(dst, b[:radix]...)
 		if radix < len(b) {
-			f.WriteByte('.')
-			f.Write(b[radix:])
+			dst = append(dst, '.')
+			dst = append(dst, b[radix:]...)
 		}
 
 	// log10(b) < scale, so before p "0s" and before b: 0.00000123456
 	default:
-		f.WriteString(zeroRadix)
-		io.CopyN(f, zeroReader{}, -int64(radix))
+		dst = append(dst, zeroRadix...)
+		dst = appendZeros(dst, -radix)
 
 		end := len(b)
 		if f.prec < end {
 			end = f.prec
 		}
-		f.Write(b[:end])
+		dst = append(dst, b[:end]...)
 	}
+	return dst
 }
 
 // TODO(eric): can we merge zeroReader and spaceReader into a "singleReader" or
@@ -328,16 +428,3 @@ func (s spaceReader) Read(p []byte) (n int, err error) {
 	}
 	return n, nil
 }
-
-// stateWrapper is a wrapper around an io.Writer to add WriteByte and
-// WriteString methods.
-type stateWrapper struct{ fmt.State }
-
-func (w stateWrapper) WriteByte(c byte) error {
-	_, err := w.Write([]byte{c})
-	return err
-}
-
-func (w stateWrapper) WriteString(s string) (int, error) {
-	return io.WriteString(w.State, s)
-}
@@ -4,9 +4,9 @@ package decimal
 
 import "strconv"
 
-const _format_name = "normalplainsci"
+const _format_name = "normalplainscieng"
 
-var _format_index = [...]uint8{0, 6, 11, 14}
+var _format_index = [...]uint8{0, 6, 11, 14, 17}
 
 func (i format) String() string {
 	if i >= format(len(_format_index)-1) {
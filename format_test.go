@@ -2,6 +2,7 @@ package decimal
 
 import (
 	"fmt"
+	"strconv"
 	"testing"
 )
 
@@ -39,6 +40,9 @@ func TestRoundString(t *testing.T) {
 	zero := makeWikiTests(ToZero, "11", "12", "11", "12")
 	pinf := makeWikiTests(ToPositiveInf, "12", "13", "11", "12")
 	ninf := makeWikiTests(ToNegativeInf, "11", "12", "12", "13")
+	down := makeWikiTests(ToNearestTowardZero, "11", "12", "11", "12")
+	odd := makeWikiTests(ToNearestOdd, "11", "13", "11", "13")
+	up05 := makeWikiTests(ZeroFiveUp, "11", "12", "11", "12")
 
 	tests := []roundStringTest{
 		{"+12345", ToNearestEven, 4, "1234"},
@@ -46,17 +50,27 @@ func TestRoundString(t *testing.T) {
 		{"+12395", ToNearestEven, 4, "1240"},
 		{"+99", ToNearestEven, 1, "10"},
 		{"+400", ToZero /* mode is irrelevant */, 1, "4"},
+		{"+123451", ToNearestEven, 4, "1235"},
+		{"+123451", ToNearestTowardZero, 4, "1235"},
+		{"+12355", ToNearestOdd, 4, "1235"},
+		{"+10501", ZeroFiveUp, 2, "11"},
+		{"+15001", ZeroFiveUp, 2, "16"},
+		{"+16999", ZeroFiveUp, 2, "16"},
+		{"+4999", ZeroFiveUp, 1, "4"},
 	}
 	tests = append(tests, even...)
 	tests = append(tests, away...)
 	tests = append(tests, zero...)
 	tests = append(tests, pinf...)
 	tests = append(tests, ninf...)
+	tests = append(tests, down...)
+	tests = append(tests, odd...)
+	tests = append(tests, up05...)
 
 	for i, test := range tests {
 		pos := test.input[0] == '+'
 		inp := test.input[1:]
-		got := roundString([]byte(inp), test.mode, pos, test.prec)
+		got := roundString([]byte(inp), test.mode, nil, pos, test.prec)
 		if string(got) != test.expect {
 			t.Fatalf(`#%d:
 [round(%q, %s, %d)]
@@ -87,3 +101,89 @@ wanted: %s
 		}
 	}
 }
+
+func TestBig_Text(t *testing.T) {
+	for i, s := range [...]struct {
+		input string
+		fmt   byte
+		prec  int
+		want  string
+	}{
+		{"123.456", 's', -1, "123.456"},
+		{"123.456", 's', 4, "123.5"},
+		{"1.23E+10", 's', -1, "1.23E+10"},
+		{"-0.0000001", 's', -1, "-1E-7"},
+		{"123.456", 'e', -1, "1.23456e+2"},
+		{"123.456", 'E', 2, "1.2E+2"},
+		{"123.456", 'f', -1, "123.456"},
+		{"123.456", 'f', 1, "123.5"},
+		{"123.456", 'f', 5, "123.45600"},
+		{"0.01", 'f', 10, "0.0100000000"},
+		{"1.23E+3", 'f', -1, "1230"},
+		{"123.456", 'g', 2, "120"},
+		{"99", 'e', 1, "1e+2"},
+		{"9.96", 's', 2, "10"},
+		{"9.96", 'f', 1, "10.0"},
+		{"-Inf", 's', -1, "-Infinity"},
+		{"NaN12", 'e', 3, "NaN12"},
+		{"1", 'x', -1, "%x"},
+	} {
+		x, _ := new(Big).SetString(s.input)
+		if got := x.Text(s.fmt, s.prec); got != s.want {
+			t.Fatalf("#%d: Text(%q, %q, %d): got %q, wanted %q",
+				i, s.input, s.fmt, s.prec, got, s.want)
+		}
+		// Text and Format should agree.
+		if s.prec >= 0 && s.fmt != 'x' {
+			want := fmt.Sprintf("%."+strconv.Itoa(s.prec)+string(s.fmt), x)
+			if got := x.Text(s.fmt, s.prec); got != want {
+				t.Fatalf("#%d: Text(%q, %q, %d): got %q, but Sprintf gave %q",
+					i, s.input, s.fmt, s.prec, got, want)
+			}
+		}
+		if got := string(x.Append([]byte("x="), s.fmt, s.prec)); got != "x="+s.want {
+			t.Fatalf("#%d: Append(%q, %q, %d): got %q", i, s.input, s.fmt, s.prec, got)
+		}
+	}
+}
+
+func TestBig_Append_Allocs(t *testing.T) {
+	x := New(-1234567, 3)
+	buf := make([]byte, 0, 64)
+	for _, c := range []byte("seEfg") {
+		n := testing.AllocsPerRun(100, func() {
+			buf = x.Append(buf[:0], c, -1)
+		})
+		if n != 0 {
+			t.Fatalf("%q: wanted 0 allocations, got %g", c, n)
+		}
+	}
+}
+
+var globBuf []byte
+
+func BenchmarkBig_Append(b *testing.B) {
+	for _, s := range [...]string{"-1234.567", "1.2345678901234567890123456789E+100"} {
+		x, _ := new(Big).SetString(s)
+		b.Run(s, func(b *testing.B) {
+			b.ReportAllocs()
+			buf := make([]byte, 0, 64)
+			for i := 0; i < b.N; i++ {
+				buf = x.Append(buf[:0], 's', -1)
+			}
+			globBuf = buf
+		})
+	}
+}
+
+var globString string
+
+func BenchmarkBig_String(b *testing.B) {
+	b.ReportAllocs()
+	x := New(-1234567, 3)
+	var s string
+	for i := 0; i < b.N; i++ {
+		s = x.String()
+	}
+	globString = s
+}
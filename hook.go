@@ -0,0 +1,173 @@
+package decimal
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Event describes an operation that raised one or more Conditions.
+type Event struct {
+	// Op is the name of the operation, e.g. "Quo".
+	Op string
+
+	// Context is the Context that performed the operation, without its
+	// Options.Hook.
+	Context Context
+
+	// Args are copies of the operation's operands, taken before the
+	// operation. Integer operands, like the scale passed to Quantize, are
+	// converted to the equivalent exemplar, so Quantize(z, 2) has the
+	// arguments z and 1E-2. Args may contain nil if an operand couldn't be
+	// parsed.
+	Args []*Big
+
+	// Result is a copy of the result of the operation.
+	Result *Big
+
+	// Raised is every Condition raised by the operation, including those
+	// that were already set in the result's Context.
+	Raised Condition
+}
+
+func (e Event) String() string {
+	var b strings.Builder
+	b.WriteString(e.Op)
+	b.WriteByte('(')
+	for i, x := range e.Args {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		if x == nil {
+			b.WriteString("<nil>")
+		} else {
+			b.WriteString(x.String())
+		}
+	}
+	fmt.Fprintf(&b, ") = %s [%d, %s]: %s",
+		e.Result, precision(e.Context), e.Context.RoundingMode, e.Raised)
+	return b.String()
+}
+
+// A Hook is notified of each operation performed by a Context that raises a
+// Condition. See Options.Hook.
+type Hook interface {
+	Notify(e Event)
+}
+
+// HookFunc is an adapter that allows an ordinary function to be used as a
+// Hook.
+type HookFunc func(e Event)
+
+// Notify calls f(e).
+func (f HookFunc) Notify(e Event) { f(e) }
+
+// Recorder is a Hook that keeps an audit log of the operations that raised
+// Conditions. For example, to record every operation that rounded a result:
+//
+//   var r decimal.Recorder
+//   r.Mask = decimal.Rounded
+//   ctx.Options = &decimal.Options{Hook: &r}
+//
+// It's safe for concurrent use.
+type Recorder struct {
+	// Mask, if non-zero, limits the log to operations that raised at least one
+	// of its Conditions.
+	Mask Condition
+
+	mu     sync.Mutex
+	events []Event
+}
+
+var _ Hook = (*Recorder)(nil)
+
+// Notify adds e to the log.
+func (r *Recorder) Notify(e Event) {
+	if r.Mask != 0 && e.Raised&r.Mask == 0 {
+		return
+	}
+	r.mu.Lock()
+	r.events = append(r.events, e)
+	r.mu.Unlock()
+}
+
+// Events returns the log, oldest first.
+func (r *Recorder) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+// Reset clears the log.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	r.events = nil
+	r.mu.Unlock()
+}
+
+// WriteTo writes the log to w, one Event per line.
+func (r *Recorder) WriteTo(w io.Writer) (n int64, err error) {
+	for _, e := range r.Events() {
+		m, err := io.WriteString(w, e.String()+"\n")
+		n += int64(m)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+var _ io.WriterTo = (*Recorder)(nil)
+
+// watched calls op, the Context method named name that sets z from args, with
+// c's Hook cleared and then notifies the Hook of the Conditions op raised.
+// Clearing the Hook means the operations that op is built from aren't
+// reported separately. Context methods call watched instead of deferring the
+// notification themselves so that, without a Hook, none of their operands
+// escape. c's Hook must not be nil.
+func (c Context) watched(name string, op func(c Context), z *Big, args ...*Big) {
+	h := c.Options.Hook
+	o := *c.Options
+	o.Hook = nil
+	c.Options = &o
+
+	e := Event{Op: name, Context: c, Args: make([]*Big, len(args))}
+	for i, x := range args {
+		if x != nil {
+			e.Args[i] = new(Big).Copy(x)
+		}
+	}
+
+	old := z.Context.Conditions
+	z.Context.Conditions = 0
+	defer func() {
+		e.Raised = z.Context.Conditions
+		z.Context.Conditions |= old
+		if e.Raised != 0 {
+			e.Result = new(Big).Copy(z)
+			h.Notify(e)
+		}
+	}()
+	op(c)
+}
+
+// Watch calls op, an operation named name that sets z from args, and notifies
+// the Hook of z's Context of the Conditions op raised, like the Context's own
+// methods do. It's meant for operations built on top of this package, like
+// those in the math and misc packages. z's Context has no Hook while op runs,
+// so the operations op is built from aren't reported separately. If z's
+// Context has no Hook, Watch just calls op.
+func Watch(name string, op func(), z *Big, args ...*Big) {
+	c := z.Context
+	if c.hook() == nil {
+		op()
+		return
+	}
+	c.watched(name, func(c Context) {
+		o := z.Context.Options
+		z.Context.Options = c.Options
+		defer func() { z.Context.Options = o }()
+		op()
+	}, z, args...)
+}
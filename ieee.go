@@ -0,0 +1,256 @@
+package decimal
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/ericlagergren/decimal/internal/arith"
+	cst "github.com/ericlagergren/decimal/internal/c"
+)
+
+// ieeeFormat describes one of the IEEE 754-2008 decimal interchange formats.
+//
+// Every format is laid out as
+//
+//   sign (1 bit) | combination (w+5 bits) | trailing significand (t bits)
+//
+// where the combination field holds the exponent and the most significant
+// bits or digit of the coefficient. BID and DPD only differ in how the
+// coefficient is spread across the combination and trailing significand
+// fields.
+type ieeeFormat struct {
+	ctx  Context // precision and exponent range
+	k    uint    // storage width in bits
+	w    uint    // width of the exponent continuation field
+	bias int     // exponent bias
+	max  word    // largest canonical coefficient, 10**p - 1
+}
+
+var (
+	ieee32  = newIEEEFormat(Context32, 32, 6, 101)
+	ieee64  = newIEEEFormat(Context64, 64, 8, 398)
+	ieee128 = newIEEEFormat(Context128, 128, 12, 6176)
+)
+
+func newIEEEFormat(ctx Context, k, w uint, bias int) ieeeFormat {
+	return ieeeFormat{
+		ctx:  ctx,
+		k:    k,
+		w:    w,
+		bias: bias,
+		max:  pow10Word(ctx.Precision).sub1(),
+	}
+}
+
+// t returns the width of the trailing significand field.
+func (f ieeeFormat) t() uint { return f.k - 6 - f.w }
+
+// etop returns the largest exponent that can be encoded.
+func (f ieeeFormat) etop() int { return f.ctx.MaxScale() - f.ctx.Precision + 1 }
+
+// maxPayload returns the largest canonical NaN payload, 10**(p-1) - 1.
+func (f ieeeFormat) maxPayload() word { return pow10Word(f.ctx.Precision - 1).sub1() }
+
+// ieeeDatum is an interchange datum that has been split into its fields but
+// not yet packed into (or after being unpacked from) a particular encoding.
+type ieeeDatum struct {
+	form  form // finite, inf, snan, or qnan, possibly with signbit set
+	coeff word // coefficient, or payload if form is a NaN
+	exp   int  // unbiased exponent
+}
+
+// datum rounds x to the format using x's RoundingMode and returns its
+// fields, along with any Conditions raised while rounding.
+func (f ieeeFormat) datum(x *Big) (d ieeeDatum, cond Condition) {
+	if x.IsNaN(0) {
+		d.form = x.form
+		// NaN payloads have at most p-1 digits; GDA says to keep the least
+		// significant ones.
+		if p := uint64(f.ctx.Precision - 1); arith.Safe(p) {
+			pow, _ := arith.Pow10(p)
+			d.coeff.lo = x.compact % pow
+		} else {
+			d.coeff.lo = x.compact
+		}
+		return d, 0
+	}
+	if x.IsInf(0) {
+		return ieeeDatum{form: x.form}, 0
+	}
+
+	ctx := f.ctx
+	ctx.RoundingMode = x.Context.RoundingMode
+	ctx.Traps = 0
+	ctx.Clamp = true
+
+	var z Big
+	z.Context = ctx
+	ctx.Set(&z, x)
+	if !z.IsFinite() {
+		return ieeeDatum{form: z.form}, z.Context.Conditions
+	}
+
+	d.form = z.form
+	d.exp = z.exp
+	if z.isCompact() {
+		d.coeff.lo = z.compact
+	} else {
+		d.coeff = bigWord(&z.unscaled)
+	}
+	return d, z.Context.Conditions
+}
+
+// canonical returns d with non-canonical coefficients and NaN payloads, as
+// well as payloads that do not fit into a Payload, replaced by zero.
+func (f ieeeFormat) canonical(d ieeeDatum) ieeeDatum {
+	switch {
+	case d.form&nan != 0:
+		if d.coeff.cmp(f.maxPayload()) > 0 || d.coeff.hi != 0 {
+			d.coeff = word{}
+		}
+	case d.form&inf != 0:
+		d.coeff = word{}
+	case d.coeff.cmp(f.max) > 0:
+		d.coeff = word{}
+	}
+	return d
+}
+
+// set sets z to the value of d and returns z.
+func (f ieeeFormat) set(z *Big, d ieeeDatum) *Big {
+	d = f.canonical(d)
+	switch {
+	case d.form&nan != 0:
+		z.form = d.form
+		z.compact = d.coeff.lo
+		return z
+	case d.form&inf != 0:
+		z.form = d.form
+		return z
+	}
+	if d.coeff.hi == 0 && d.coeff.lo != cst.Inflated {
+		return z.setTriple(d.coeff.lo, d.form&signbit, d.exp)
+	}
+	arith.Set128(&z.unscaled, d.coeff.hi, d.coeff.lo)
+	z.form = d.form & signbit
+	z.exp = d.exp
+	return z.norm()
+}
+
+// word is an unsigned 128-bit integer. It's used both as an interchange datum
+// and as a coefficient. Narrower formats only use the least significant bits.
+type word struct{ hi, lo uint64 }
+
+// bigWord returns the least significant 128 bits of x.
+func bigWord(x *big.Int) (w word) {
+	for i, v := range x.Bits() {
+		switch s := uint(i * bits.UintSize); {
+		case s < 64:
+			w.lo |= uint64(v) << s
+		case s < 128:
+			w.hi |= uint64(v) << (s - 64)
+		}
+	}
+	return w
+}
+
+// pow10Word returns 10**n. The result is undefined if n > 38.
+func pow10Word(n int) word {
+	w := word{lo: 1}
+	for i := 0; i < n; i++ {
+		w = w.mul64(10)
+	}
+	return w
+}
+
+func (x word) isZero() bool { return x.hi|x.lo == 0 }
+
+func (x word) cmp(y word) int {
+	if x.hi != y.hi {
+		return arith.Cmp(x.hi, y.hi)
+	}
+	return arith.Cmp(x.lo, y.lo)
+}
+
+func (x word) or(y word) word { return word{hi: x.hi | y.hi, lo: x.lo | y.lo} }
+
+func (x word) xor(y word) word { return word{hi: x.hi ^ y.hi, lo: x.lo ^ y.lo} }
+
+func (x word) lsh(n uint) word {
+	switch {
+	case n == 0:
+		return x
+	case n >= 128:
+		return word{}
+	case n >= 64:
+		return word{hi: x.lo << (n - 64)}
+	}
+	return word{hi: x.hi<<n | x.lo>>(64-n), lo: x.lo << n}
+}
+
+func (x word) rsh(n uint) word {
+	switch {
+	case n == 0:
+		return x
+	case n >= 128:
+		return word{}
+	case n >= 64:
+		return word{lo: x.hi >> (n - 64)}
+	}
+	return word{hi: x.hi >> n, lo: x.lo>>n | x.hi<<(64-n)}
+}
+
+// mask returns the n least significant bits of x.
+func (x word) mask(n uint) word {
+	switch {
+	case n >= 128:
+		return x
+	case n >= 64:
+		return word{hi: x.hi & (1<<(n-64) - 1), lo: x.lo}
+	}
+	return word{lo: x.lo & (1<<n - 1)}
+}
+
+// bit returns the nth bit of x.
+func (x word) bit(n uint) uint64 { return x.rsh(n).lo & 1 }
+
+// sub1 returns x - 1.
+func (x word) sub1() word {
+	lo, b := bits.Sub64(x.lo, 1, 0)
+	return word{hi: x.hi - b, lo: lo}
+}
+
+// add64 returns the least significant 128 bits of x + y.
+func (x word) add64(y uint64) word {
+	lo, c := bits.Add64(x.lo, y, 0)
+	return word{hi: x.hi + c, lo: lo}
+}
+
+// mul64 returns the least significant 128 bits of x * y.
+func (x word) mul64(y uint64) word {
+	hi, lo := bits.Mul64(x.lo, y)
+	return word{hi: x.hi*y + hi, lo: lo}
+}
+
+// quoRem64 returns x / y and x % y.
+func (x word) quoRem64(y uint64) (q word, r uint64) {
+	q.hi, r = bits.Div64(0, x.hi, y)
+	q.lo, r = bits.Div64(r, x.lo, y)
+	return q, r
+}
+
+// bytes stores the n least significant bytes of x into b in big-endian order.
+func (x word) bytes(b []byte) {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = byte(x.lo)
+		x = x.rsh(8)
+	}
+}
+
+// wordOf returns the big-endian bytes of b as a word.
+func wordOf(b []byte) (x word) {
+	for _, c := range b {
+		x = x.lsh(8).or(word{lo: uint64(c)})
+	}
+	return x
+}
@@ -0,0 +1,452 @@
+package decimal
+
+import (
+	"math/bits"
+
+	"github.com/ericlagergren/decimal/internal/arith"
+)
+
+// The methods in this file implement arithmetic on interchange data for the
+// fixed-size Decimal64 and Decimal128 types. Results are always rounded with
+// ToNearestEven and fit the format, so they can be packed without going
+// through datum again.
+
+// nans returns the quiet NaN resulting from op, where x or y is a NaN. It
+// mirrors Big.checkNaNs.
+func nans(x, y ieeeDatum, op Payload) (ieeeDatum, Condition) {
+	src := y
+	var cond Condition
+	switch {
+	case (x.form|y.form)&snan != 0:
+		cond = InvalidOperation
+		if x.form&snan != 0 {
+			src = x
+		}
+	case x.form&nan != 0:
+		src = x
+	}
+	z := ieeeDatum{form: qnan | src.form&signbit, coeff: src.coeff}
+	if z.coeff == (word{}) {
+		z.coeff.lo = uint64(op)
+	}
+	return z, cond
+}
+
+// invalid returns the quiet NaN resulting from an invalid operation.
+func invalid(cond Condition, op Payload) (ieeeDatum, Condition) {
+	return ieeeDatum{form: qnan, coeff: word{lo: uint64(op)}}, InvalidOperation | cond
+}
+
+// add returns x + y, or x - y if sub is true.
+func (f ieeeFormat) add(x, y ieeeDatum, sub bool) (ieeeDatum, Condition) {
+	if (x.form|y.form)&nan != 0 {
+		if sub {
+			return nans(x, y, subtraction)
+		}
+		return nans(x, y, addition)
+	}
+	if sub {
+		y.form ^= signbit
+	}
+	if (x.form|y.form)&inf != 0 {
+		if x.form&inf == 0 {
+			return y, 0
+		}
+		if y.form&inf != 0 && x.form != y.form {
+			// +Inf + -Inf
+			if sub {
+				return invalid(0, subinfinf)
+			}
+			return invalid(0, addinfinf)
+		}
+		return x, 0
+	}
+
+	// Make x the operand with the larger exponent.
+	if x.exp < y.exp {
+		x, y = y, x
+	}
+	xc, yc := u256Of(x.coeff), u256Of(y.coeff)
+	exp := y.exp
+	if !xc.isZero() {
+		// Digits of y more than p+3 places below x's most significant digit
+		// cannot affect the rounded result, except for whether or not it's
+		// exact. Collapse them into a single sticky digit.
+		if e := x.exp + xc.digits() - f.ctx.Precision - 3; y.exp < e {
+			q, rd, sticky := yc.rshDigits(e - y.exp)
+			yc = q.mul64(10)
+			if rd != 0 || sticky {
+				yc[0] |= 1
+			}
+			exp = e - 1
+		}
+		xc = xc.mulPow10(x.exp - exp)
+	}
+
+	sign := x.form & signbit
+	switch {
+	case x.form == y.form:
+		xc = xc.add(yc)
+	case xc.cmp(yc) >= 0:
+		xc = xc.sub(yc)
+	default:
+		xc = yc.sub(xc)
+		sign = y.form & signbit
+	}
+	if xc.isZero() && x.form != y.form {
+		sign = 0
+	}
+	return f.round(sign, xc, exp, false)
+}
+
+// mul returns x * y.
+func (f ieeeFormat) mul(x, y ieeeDatum) (ieeeDatum, Condition) {
+	if (x.form|y.form)&nan != 0 {
+		return nans(x, y, multiplication)
+	}
+	sign := (x.form ^ y.form) & signbit
+	if (x.form|y.form)&inf != 0 {
+		if (x.form&inf == 0 && x.coeff.isZero()) ||
+			(y.form&inf == 0 && y.coeff.isZero()) {
+			// 0 * ±Inf
+			return invalid(0, mul0inf)
+		}
+		return ieeeDatum{form: inf | sign}, 0
+	}
+	c := u256Of(x.coeff).mul(u256Of(y.coeff))
+	return f.round(sign, c, x.exp+y.exp, false)
+}
+
+// quo returns x / y.
+func (f ieeeFormat) quo(x, y ieeeDatum) (ieeeDatum, Condition) {
+	if (x.form|y.form)&nan != 0 {
+		return nans(x, y, division)
+	}
+	sign := (x.form ^ y.form) & signbit
+	if (x.form|y.form)&inf != 0 {
+		if x.form&inf != 0 {
+			if y.form&inf != 0 {
+				// ±Inf / ±Inf
+				return invalid(0, quoinfinf)
+			}
+			return ieeeDatum{form: inf | sign}, 0
+		}
+		// x / ±Inf
+		return ieeeDatum{form: sign, exp: f.ctx.etiny()}, Clamped
+	}
+	if y.coeff.isZero() {
+		if x.coeff.isZero() {
+			// 0 / 0
+			return invalid(DivisionUndefined, quo00)
+		}
+		return ieeeDatum{form: inf | sign}, DivisionByZero
+	}
+
+	ideal := x.exp - y.exp
+	if x.coeff.isZero() {
+		return f.round(sign, u256{}, ideal, false)
+	}
+
+	// Scale x so the quotient has at least p+1 digits, which leaves a digit
+	// for rounding.
+	xc, yc := u256Of(x.coeff), u256Of(y.coeff)
+	shift := f.ctx.Precision + 1 + yc.digits() - xc.digits()
+	if shift < 0 {
+		shift = 0
+	}
+	q, r := xc.mulPow10(shift).quoRem(yc)
+	exp := ideal - shift
+	if r.isZero() {
+		// The quotient is exact, so remove trailing zeros until it reaches
+		// the ideal exponent.
+		for exp < ideal {
+			q2, r2 := q.quoRem64(10)
+			if r2 != 0 {
+				break
+			}
+			q = q2
+			exp++
+		}
+	}
+	return f.round(sign, q, exp, !r.isZero())
+}
+
+// cmp compares x and y like Big.Cmp. The result is undefined if either x or y
+// is a NaN.
+func (ieeeFormat) cmp(x, y ieeeDatum) int {
+	if (x.form|y.form)&nan != 0 {
+		return 0
+	}
+	xs, ys := x.ord(), y.ord()
+	if xs != ys {
+		if xs > ys {
+			return +1
+		}
+		return -1
+	}
+	if xs != -1 && xs != +1 {
+		return 0
+	}
+
+	// Compare the adjusted exponents first, then align the coefficients.
+	xc, yc := u256Of(x.coeff), u256Of(y.coeff)
+	var r int
+	if xa, ya := x.exp+xc.digits(), y.exp+yc.digits(); xa != ya {
+		r = -1
+		if xa > ya {
+			r = +1
+		}
+	} else if x.exp > y.exp {
+		r = xc.mulPow10(x.exp - y.exp).cmp(yc)
+	} else {
+		r = xc.cmp(yc.mulPow10(y.exp - x.exp))
+	}
+	return r * xs
+}
+
+// ord returns -2 for -Inf, -1 for negative values, 0 for zeros, +1 for
+// positive values, and +2 for +Inf.
+func (d ieeeDatum) ord() int {
+	var r int
+	switch {
+	case d.form&inf != 0:
+		r = 2
+	case !d.coeff.isZero():
+		r = 1
+	}
+	if d.form&signbit != 0 {
+		r = -r
+	}
+	return r
+}
+
+// round rounds sign × c × 10**exp to the format, treating the result as
+// slightly larger in magnitude if sticky is true, which is only allowed if c
+// has more than p digits. It handles overflow, subnormal results, and clamping
+// the exponent the same way Context.fix does.
+func (f ieeeFormat) round(sign form, c u256, exp int, sticky bool) (ieeeDatum, Condition) {
+	var (
+		p     = f.ctx.Precision
+		emin  = f.ctx.MinScale()
+		etiny = f.ctx.etiny()
+		etop  = f.etop()
+		cond  Condition
+	)
+	if c.isZero() && !sticky {
+		switch {
+		case exp < etiny:
+			exp = etiny
+			cond |= Clamped
+		case exp > etop:
+			exp = etop
+			cond |= Clamped
+		}
+		return ieeeDatum{form: sign, exp: exp}, cond
+	}
+
+	n := c.digits()
+	if exp+n-1 < emin {
+		cond |= Subnormal
+	}
+	shift := n - p
+	if s := etiny - exp; s > shift {
+		shift = s
+	}
+	if shift > 0 {
+		q, rd, st := c.rshDigits(shift)
+		c, sticky = q, sticky || st
+		exp += shift
+		cond |= Rounded
+		if rd != 0 || sticky {
+			cond |= Inexact
+			if rd > 5 || rd == 5 && (sticky || c[0]&1 != 0) {
+				c = c.add(u256{1})
+				if c == u256pow10[p] {
+					c = u256pow10[p-1]
+					exp++
+				}
+			}
+		}
+	}
+	if cond&(Subnormal|Inexact) == Subnormal|Inexact {
+		cond |= Underflow
+		if c.isZero() {
+			cond |= Clamped
+		}
+	}
+
+	if !c.isZero() && exp+c.digits()-1 > f.ctx.MaxScale() {
+		return ieeeDatum{form: inf | sign}, cond | Overflow | Inexact | Rounded
+	}
+	if exp > etop {
+		c = c.mulPow10(exp - etop)
+		exp = etop
+		cond |= Clamped
+	}
+	return ieeeDatum{form: sign, coeff: c.word(), exp: exp}, cond
+}
+
+// u256 is an unsigned 256-bit integer, least significant word first. It's wide
+// enough to hold the exact product of two decimal128 coefficients.
+type u256 [4]uint64
+
+// u256pow10[i] is 10**i.
+var u256pow10 [78]u256
+
+func init() {
+	u256pow10[0] = u256{1}
+	for i := 1; i < len(u256pow10); i++ {
+		u256pow10[i] = u256pow10[i-1].mul64(10)
+	}
+}
+
+func u256Of(x word) u256 { return u256{x.lo, x.hi} }
+
+// word returns the least significant 128 bits of x.
+func (x u256) word() word { return word{hi: x[1], lo: x[0]} }
+
+func (x u256) isZero() bool { return x[0]|x[1]|x[2]|x[3] == 0 }
+
+func (x u256) cmp(y u256) int {
+	for i := len(x) - 1; i >= 0; i-- {
+		if x[i] != y[i] {
+			return arith.Cmp(x[i], y[i])
+		}
+	}
+	return 0
+}
+
+func (x u256) bitLen() int {
+	for i := len(x) - 1; i >= 0; i-- {
+		if x[i] != 0 {
+			return i*64 + bits.Len64(x[i])
+		}
+	}
+	return 0
+}
+
+// digits returns the number of decimal digits in x. Zero has one digit.
+func (x u256) digits() int {
+	// log10(2) ~= 1233/4096
+	n := (x.bitLen() + 1) * 1233 >> 12
+	if x.cmp(u256pow10[n]) >= 0 {
+		n++
+	}
+	if n == 0 {
+		return 1
+	}
+	return n
+}
+
+func (x u256) add(y u256) (z u256) {
+	var c uint64
+	for i := range x {
+		z[i], c = bits.Add64(x[i], y[i], c)
+	}
+	return z
+}
+
+func (x u256) sub(y u256) (z u256) {
+	var b uint64
+	for i := range x {
+		z[i], b = bits.Sub64(x[i], y[i], b)
+	}
+	return z
+}
+
+// mul64 returns the least significant 256 bits of x * y.
+func (x u256) mul64(y uint64) (z u256) {
+	var c uint64
+	for i := range x {
+		hi, lo := bits.Mul64(x[i], y)
+		var cc uint64
+		z[i], cc = bits.Add64(lo, c, 0)
+		c = hi + cc
+	}
+	return z
+}
+
+// mul returns the least significant 256 bits of x * y.
+func (x u256) mul(y u256) (z u256) {
+	for j := range y {
+		if y[j] == 0 {
+			continue
+		}
+		var c uint64
+		for i := 0; i+j < len(z); i++ {
+			hi, lo := bits.Mul64(x[i], y[j])
+			var cc uint64
+			lo, cc = bits.Add64(lo, z[i+j], 0)
+			hi += cc
+			z[i+j], cc = bits.Add64(lo, c, 0)
+			c = hi + cc
+		}
+	}
+	return z
+}
+
+// mulPow10 returns the least significant 256 bits of x * 10**n.
+func (x u256) mulPow10(n int) u256 {
+	for ; n >= arith.PowTabLen; n -= arith.PowTabLen - 1 {
+		p, _ := arith.Pow10(arith.PowTabLen - 1)
+		x = x.mul64(p)
+	}
+	p, _ := arith.Pow10(uint64(n))
+	return x.mul64(p)
+}
+
+// quoRem64 returns x / y and x % y.
+func (x u256) quoRem64(y uint64) (q u256, r uint64) {
+	for i := len(x) - 1; i >= 0; i-- {
+		q[i], r = bits.Div64(r, x[i], y)
+	}
+	return q, r
+}
+
+// quoRem returns x / y and x % y.
+func (x u256) quoRem(y u256) (q, r u256) {
+	if y[1]|y[2]|y[3] == 0 {
+		q, r[0] = x.quoRem64(y[0])
+		return q, r
+	}
+	// Schoolbook binary long division. y has more than 64 bits, so this is
+	// only hit by decimal128.
+	for i := x.bitLen() - 1; i >= 0; i-- {
+		r = r.lsh1()
+		r[0] |= x[i/64] >> uint(i%64) & 1
+		if r.cmp(y) >= 0 {
+			r = r.sub(y)
+			q[i/64] |= 1 << uint(i%64)
+		}
+	}
+	return q, r
+}
+
+func (x u256) lsh1() (z u256) {
+	for i := len(x) - 1; i > 0; i-- {
+		z[i] = x[i]<<1 | x[i-1]>>63
+	}
+	z[0] = x[0] << 1
+	return z
+}
+
+// rshDigits returns x / 10**n, n > 0, along with the last digit shifted out
+// and whether any of the other digits shifted out were nonzero.
+func (x u256) rshDigits(n int) (q u256, rd uint64, sticky bool) {
+	if n > len(u256pow10) {
+		return u256{}, 0, !x.isZero()
+	}
+	for n > 1 {
+		k := n - 1
+		if k >= arith.PowTabLen {
+			k = arith.PowTabLen - 1
+		}
+		p, _ := arith.Pow10(uint64(k))
+		var r uint64
+		x, r = x.quoRem64(p)
+		sticky = sticky || r != 0
+		n -= k
+	}
+	q, rd = x.quoRem64(10)
+	return q, rd, sticky
+}
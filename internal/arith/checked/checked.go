@@ -3,6 +3,7 @@ package checked
 
 import (
 	"math/big"
+	"math/bits"
 
 	"github.com/ericlagergren/decimal/internal/arith"
 )
@@ -42,3 +43,12 @@ func MulBigPow10(z, x *big.Int, n uint64) *big.Int {
 	}
 	return z.Mul(x, arith.BigPow10(n))
 }
+
+// Mul128 computes (x1, x0) * y and a bool indicating whether the product fits
+// into 128 bits.
+func Mul128(x1, x0, y uint64) (z1, z0 uint64, ok bool) {
+	h0, z0 := bits.Mul64(x0, y)
+	h1, l1 := bits.Mul64(x1, y)
+	z1, c := bits.Add64(h0, l1, 0)
+	return z1, z0, h1 == 0 && c == 0
+}
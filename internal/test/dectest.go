@@ -0,0 +1,316 @@
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/ericlagergren/decimal/math"
+	"github.com/ericlagergren/decimal/misc"
+	"github.com/ericlagergren/decimal/suite"
+)
+
+// DecResult tallies the outcome of the .decTest cases for one operation.
+type DecResult struct {
+	Pass, Fail, Skip int
+}
+
+// DecMatrix maps each .decTest operation to its results.
+type DecMatrix map[string]*DecResult
+
+// Run executes each case against decimal.Big and records the outcome in m.
+// Cases that use an unsupported operation, rounding mode, or directive are
+// skipped. If fail is not nil, it's called with each failing case along with
+// the result and Conditions decimal.Big produced.
+func (m DecMatrix) Run(cases []suite.DecCase, fail func(c suite.DecCase, got string, cond decimal.Condition)) {
+	for _, c := range cases {
+		r, ok := m[c.Op]
+		if !ok {
+			r = new(DecResult)
+			m[c.Op] = r
+		}
+		got, cond, ok := execDec(c)
+		switch {
+		case !ok:
+			r.Skip++
+		case decEqual(got, cond, c):
+			r.Pass++
+		default:
+			r.Fail++
+			if fail != nil {
+				fail(c, got, cond)
+			}
+		}
+	}
+}
+
+// String returns m as a table sorted by operation.
+func (m DecMatrix) String() string {
+	ops := make([]string, 0, len(m))
+	for op := range m {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+
+	var b bytes.Buffer
+	w := tabwriter.NewWriter(&b, 0, 8, 1, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "op\tpass\tfail\tskip\t")
+	var t DecResult
+	for _, op := range ops {
+		r := m[op]
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t\n", op, r.Pass, r.Fail, r.Skip)
+		t.Pass += r.Pass
+		t.Fail += r.Fail
+		t.Skip += r.Skip
+	}
+	fmt.Fprintf(w, "total\t%d\t%d\t%d\t\n", t.Pass, t.Fail, t.Skip)
+	w.Flush()
+	return b.String()
+}
+
+// decEqual reports whether got and cond match c's expected output. NaN
+// results match regardless of payload unless c expects a specific one, since
+// decimal.Big adds diagnostic payloads to the NaNs it creates.
+func decEqual(got string, cond decimal.Condition, c suite.DecCase) bool {
+	if cond != decimal.Condition(c.Excep) {
+		return false
+	}
+	want := string(c.Output)
+	if got == want {
+		return true
+	}
+	trim := func(s string) string { return strings.TrimRight(s, "0123456789") }
+	return strings.HasSuffix(trim(want), "NaN") && trim(got) == want
+}
+
+var decModes = map[string]decimal.RoundingMode{
+	"ceiling":   decimal.ToPositiveInf,
+	"down":      decimal.ToZero,
+	"floor":     decimal.ToNegativeInf,
+	"half_down": decimal.ToNearestTowardZero,
+	"half_even": decimal.ToNearestEven,
+	"half_up":   decimal.ToNearestAway,
+	"up":        decimal.AwayFromZero,
+	"05up":      decimal.ZeroFiveUp,
+}
+
+func decContext(c suite.DecCase) (decimal.Context, bool) {
+	mode, ok := decModes[c.Rounding]
+	// The subset arithmetic isn't supported.
+	if !ok || !c.Extended {
+		return decimal.Context{}, false
+	}
+	return decimal.Context{
+		Precision:     c.Precision,
+		RoundingMode:  mode,
+		OperatingMode: decimal.GDA,
+		Clamp:         c.Clamp,
+		Options: &decimal.Options{
+			MaxScale: c.MaxExponent,
+			MinScale: c.MinExponent,
+		},
+	}, true
+}
+
+// decConv are the operations whose operand is a string that's converted
+// using the Context instead of an exact number.
+var decConv = map[string]bool{
+	"apply": true,
+	"tosci": true,
+	"toeng": true,
+}
+
+// execDec performs c's operation and returns its result and Conditions. It
+// returns false if c cannot be run.
+func execDec(c suite.DecCase) (got string, cond decimal.Condition, ok bool) {
+	ctx, ok := decContext(c)
+	if !ok || c.Output == "#" || c.Output == "?" {
+		return "", 0, false
+	}
+
+	z := decimal.WithContext(ctx)
+	if decConv[c.Op] {
+		if len(c.Inputs) != 1 {
+			return "", 0, false
+		}
+		if _, ok := ctx.SetString(z, string(c.Inputs[0])); !ok {
+			return "NaN", z.Context.Conditions | decimal.ConversionSyntax, true
+		}
+		if c.Op == "toeng" {
+			return z.EngString(), z.Context.Conditions, true
+		}
+		return z.String(), z.Context.Conditions, true
+	}
+
+	in := make([]*decimal.Big, len(c.Inputs))
+	for i, s := range c.Inputs {
+		if s == "#" {
+			return "", 0, false
+		}
+		in[i] = decimal.WithContext(ctx)
+		if _, ok := in[i].SetString(string(s)); !ok {
+			return "", 0, false
+		}
+	}
+
+	defer func() {
+		if err := recover(); err != nil {
+			got, cond, ok = fmt.Sprint("panic: ", err), 0, true
+		}
+	}()
+
+	if s, ok := decOp(c.Op, z, in); ok {
+		got = s
+	} else if fn, ok := decOps[c.Op]; ok && fn.arity == len(in) {
+		got = fn.fn(z, in).String()
+	} else {
+		return "", 0, false
+	}
+
+	cond = z.Context.Conditions
+	for _, x := range in {
+		cond |= x.Context.Conditions
+	}
+	return got, cond, true
+}
+
+// decOp performs the operations that don't produce a *decimal.Big.
+func decOp(op string, z *decimal.Big, in []*decimal.Big) (string, bool) {
+	switch op {
+	case "class":
+		if len(in) == 1 {
+			return in[0].Class(), true
+		}
+	case "samequantum":
+		if len(in) == 2 {
+			x, y := in[0], in[1]
+			var same bool
+			switch {
+			case x.IsNaN(0) || y.IsNaN(0):
+				same = x.IsNaN(0) && y.IsNaN(0)
+			case x.IsInf(0) || y.IsInf(0):
+				same = x.IsInf(0) && y.IsInf(0)
+			default:
+				same = misc.SameQuantum(x, y)
+			}
+			if same {
+				return "1", true
+			}
+			return "0", true
+		}
+	}
+	return "", false
+}
+
+var decOps = map[string]struct {
+	arity int
+	fn    func(z *decimal.Big, in []*decimal.Big) *decimal.Big
+}{
+	"abs":           {1, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return z.Abs(in[0]) }},
+	"add":           {2, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return z.Add(in[0], in[1]) }},
+	"and":           {2, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return misc.And(z, in[0], in[1]) }},
+	"canonical":     {1, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return misc.Canonical(z, in[0]) }},
+	"compare":       {2, decCmp((*decimal.Big).Cmp)},
+	"comparesig":    {2, decCmpSignal},
+	"comparetotal":  {2, decCmpTotal(misc.CmpTotal)},
+	"comparetotmag": {2, decCmpTotal(misc.CmpTotalAbs)},
+	"copy":          {1, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return z.Copy(in[0]) }},
+	"copyabs":       {1, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return misc.CopyAbs(z, in[0]) }},
+	"copynegate":    {1, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return misc.CopyNeg(z, in[0]) }},
+	"copysign":      {2, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return z.CopySign(in[0], in[1]) }},
+	"divide":        {2, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return z.Quo(in[0], in[1]) }},
+	"divideint":     {2, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return z.QuoInt(in[0], in[1]) }},
+	"exp":           {1, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return math.Exp(z, in[0]) }},
+	"fma":           {3, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return z.FMA(in[0], in[1], in[2]) }},
+	"invert":        {1, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return misc.Invert(z, in[0]) }},
+	"ln":            {1, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return math.Log(z, in[0]) }},
+	"log10":         {1, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return math.Log10(z, in[0]) }},
+	"logb":          {1, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return misc.Logb(z, in[0]) }},
+	"minus":         {1, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return z.Neg(in[0]) }},
+	"multiply":      {2, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return z.Mul(in[0], in[1]) }},
+	"nextminus":     {1, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return misc.NextMinus(z, in[0]) }},
+	"nextplus":      {1, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return misc.NextPlus(z, in[0]) }},
+	"nexttoward":    {2, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return misc.NextToward(z, in[0], in[1]) }},
+	"or":            {2, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return misc.Or(z, in[0], in[1]) }},
+	"plus":          {1, decPlus},
+	"power":         {2, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return math.Pow(z, in[0], in[1]) }},
+	"quantize":      {2, decQuantize},
+	"reduce":        {1, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return z.Context.Reduce(z.Copy(in[0])) }},
+	"remainder":     {2, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return z.Rem(in[0], in[1]) }},
+	"remaindernear": {2, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return z.RemNear(in[0], in[1]) }},
+	"rotate":        {2, decShift(misc.Rotate)},
+	"scaleb":        {2, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return misc.Scaleb(z, in[0], in[1]) }},
+	"squareroot":    {1, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return math.Sqrt(z, in[0]) }},
+	"subtract":      {2, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return z.Sub(in[0], in[1]) }},
+	"tointegral":    {1, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return z.Copy(in[0]).RoundToIntegralValue() }},
+	"tointegralx":   {1, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return z.Copy(in[0]).RoundToInt() }},
+	"xor":           {2, func(z *decimal.Big, in []*decimal.Big) *decimal.Big { return misc.Xor(z, in[0], in[1]) }},
+}
+
+func decCmp(fn func(x, y *decimal.Big) int) func(z *decimal.Big, in []*decimal.Big) *decimal.Big {
+	return func(z *decimal.Big, in []*decimal.Big) *decimal.Big {
+		r := fn(in[0], in[1])
+		if z.CheckNaNs(in[0], in[1]) {
+			return z
+		}
+		return z.SetMantScale(int64(r), 0)
+	}
+}
+
+func decCmpSignal(z *decimal.Big, in []*decimal.Big) *decimal.Big {
+	r, ok := z.Context.CmpSignal(in[0], in[1])
+	if !ok {
+		z.CheckNaNs(in[0], in[1])
+		return z
+	}
+	return z.SetMantScale(int64(r), 0)
+}
+
+func decCmpTotal(fn func(x, y *decimal.Big) int) func(z *decimal.Big, in []*decimal.Big) *decimal.Big {
+	return func(z *decimal.Big, in []*decimal.Big) *decimal.Big {
+		return z.SetMantScale(int64(fn(in[0], in[1])), 0)
+	}
+}
+
+// decPlus computes 0 + x.
+func decPlus(z *decimal.Big, in []*decimal.Big) *decimal.Big {
+	x := in[0]
+	var zero decimal.Big
+	if x.IsFinite() {
+		zero.SetMantScale(0, x.Scale())
+	}
+	return z.Add(&zero, x)
+}
+
+func decQuantize(z *decimal.Big, in []*decimal.Big) *decimal.Big {
+	x, y := in[0], in[1]
+	if z.CheckNaNs(x, y) {
+		return z
+	}
+	if x.IsInf(0) || y.IsInf(0) {
+		if x.IsInf(0) && y.IsInf(0) {
+			return z.Copy(x)
+		}
+		z.Context.Conditions |= decimal.InvalidOperation
+		return z.SetNaN(false)
+	}
+	return z.Copy(x).Quantize(y.Scale())
+}
+
+func decShift(fn func(z, x *decimal.Big, n int) *decimal.Big) func(z *decimal.Big, in []*decimal.Big) *decimal.Big {
+	return func(z *decimal.Big, in []*decimal.Big) *decimal.Big {
+		x, y := in[0], in[1]
+		if z.CheckNaNs(x, y) {
+			return z
+		}
+		n, ok := y.Int64()
+		if !ok || !y.IsInt() || y.Scale() != 0 || n != int64(int(n)) {
+			z.Context.Conditions |= decimal.InvalidOperation
+			return z.SetNaN(false)
+		}
+		return fn(z, x, int(n))
+	}
+}
@@ -34,6 +34,7 @@ const (
 	Add        Test = "addition"
 	Class      Test = "class"
 	Cmp        Test = "comparison"
+	CmpSignal  Test = "compare-signal"
 	CTR        Test = "convert-to-rat"
 	CFS        Test = "convert-from-string"
 	CTS        Test = "convert-to-string"
@@ -46,6 +47,7 @@ const (
 	Neg        Test = "negation"
 	NextMinus  Test = "next-minus"
 	NextPlus   Test = "next-plus"
+	NextToward Test = "next-toward"
 	Pow        Test = "power"
 	Quant      Test = "quantization"
 	Quo        Test = "division"
@@ -53,6 +55,8 @@ const (
 	Reduce     Test = "reduction"
 	Rem        Test = "remainder"
 	RoundToInt Test = "round-to-integral-exact"
+	RoundToIV  Test = "round-to-integral-value"
+	Scaleb     Test = "scale-b"
 	Shift      Test = "shift"
 	Sign       Test = "sign"
 	Signbit    Test = "signbit"
@@ -74,6 +78,7 @@ func (tst Test) Test(t *testing.T) {
 var nilary = map[Test]func(z *decimal.Big) *decimal.Big{
 	Reduce:     (*decimal.Big).Reduce,
 	RoundToInt: (*decimal.Big).RoundToInt,
+	RoundToIV:  (*decimal.Big).RoundToIntegralValue,
 }
 
 var unary = map[Test]func(z, x *decimal.Big) *decimal.Big{
@@ -82,18 +87,21 @@ var unary = map[Test]func(z, x *decimal.Big) *decimal.Big{
 	Exp:       math.Exp,
 	Log:       math.Log,
 	Log10:     math.Log10,
+	Logb:      misc.Logb,
 	NextMinus: misc.NextMinus,
 	NextPlus:  misc.NextPlus,
 	Sqrt:      math.Sqrt,
 }
 
 var binary = map[Test]func(z, x, y *decimal.Big) *decimal.Big{
-	Add:    (*decimal.Big).Add,
-	Mul:    (*decimal.Big).Mul,
-	Quo:    (*decimal.Big).Quo,
-	QuoInt: (*decimal.Big).QuoInt,
-	Rem:    (*decimal.Big).Rem,
-	Sub:    (*decimal.Big).Sub,
+	Add:        (*decimal.Big).Add,
+	Mul:        (*decimal.Big).Mul,
+	Quo:        (*decimal.Big).Quo,
+	QuoInt:     (*decimal.Big).QuoInt,
+	NextToward: misc.NextToward,
+	Rem:        (*decimal.Big).Rem,
+	Scaleb:     misc.Scaleb,
+	Sub:        (*decimal.Big).Sub,
 	// The Python version we test against has rounding errors of 1 ULP. So test
 	// to see if we're within 1 ULP.
 	// Pow:    math.Pow,
@@ -121,6 +129,13 @@ func (c *scase) execute(name Test) {
 			r, _, snan := c.Cmp()
 			c.Assert(rv, r)
 			c.Assert(snan, c.x.Context.Conditions&decimal.InvalidOperation != 0)
+		case CmpSignal:
+			var ctx decimal.Context
+			rv, ok := ctx.CmpSignal(c.x, c.y)
+			r, nan, _ := c.Cmp()
+			c.Assert(rv, r)
+			c.Assert(nan, !ok)
+			c.Assert(nan, ctx.Conditions&decimal.InvalidOperation != 0)
 		case Shift:
 			//v, _ := c.y.Int64()
 			//c.Check(misc.Shift(c.z, c.x, int(v)))
@@ -0,0 +1,406 @@
+// Package locale parses and formats decimals using the conventions of
+// different locales, like "1.234,56" in German or "١٬٢٣٤٫٥٦" in Egyptian
+// Arabic.
+//
+// Locales are looked up from a table built into the package, which is based
+// on the Unicode CLDR data for common locales, so no external data is needed.
+package locale
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// Locale describes how a locale writes numbers. The zero value of each field
+// means the default noted in its comment, so the zero Locale writes numbers
+// like decimal.Big.Text without grouping separators.
+type Locale struct {
+	// Tag is the BCP 47 language tag, e.g. "de-CH".
+	Tag string
+
+	// Decimal is the decimal separator. The default is ".".
+	Decimal string
+
+	// Group is the grouping (thousands) separator. It's not used if
+	// PrimaryGroup is zero.
+	Group string
+
+	// PrimaryGroup is the number of digits in the group closest to the
+	// decimal separator, and SecondaryGroup is the number of digits in the
+	// other groups. For example, hi-IN writes 1,23,45,678 with a
+	// PrimaryGroup of 3 and a SecondaryGroup of 2. PrimaryGroup is zero if
+	// digits aren't grouped, and the default SecondaryGroup is PrimaryGroup.
+	PrimaryGroup, SecondaryGroup int
+
+	// MinGrouping is the minimum number of digits in the leftmost group for
+	// grouping to be used. For example, es has a MinGrouping of 2, so it
+	// writes 1234 but 12.345. The default is 1.
+	MinGrouping int
+
+	// Minus and Plus are the signs, which can include bidirectional marks.
+	// The defaults are "-" and "+".
+	Minus, Plus string
+
+	// Exponent separates the coefficient from the exponent in scientific
+	// notation. The default is "E".
+	Exponent string
+
+	// Zero is the digit zero of the locale's digits, which must be the first
+	// of ten consecutive code points, e.g. '٠' (U+0660) for Arabic-Indic
+	// digits. The default is '0'.
+	Zero rune
+}
+
+func (l Locale) decimal() string  { return orDefault(l.Decimal, ".") }
+func (l Locale) minus() string    { return orDefault(l.Minus, "-") }
+func (l Locale) plus() string     { return orDefault(l.Plus, "+") }
+func (l Locale) exponent() string { return orDefault(l.Exponent, "E") }
+
+func (l Locale) zero() rune {
+	if l.Zero == 0 {
+		return '0'
+	}
+	return l.Zero
+}
+
+func (l Locale) secondaryGroup() int {
+	if l.SecondaryGroup == 0 {
+		return l.PrimaryGroup
+	}
+	return l.SecondaryGroup
+}
+
+func (l Locale) minGrouping() int {
+	if l.MinGrouping == 0 {
+		return 1
+	}
+	return l.MinGrouping
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// Lookup returns the Locale for tag, which is a BCP 47 language tag like
+// "de-CH" or "de_CH" and is matched case-insensitively. If there is no entry
+// for tag's region, the entry for its language is used, so "de-LU" gets the
+// Locale for "de". The Unicode extension "-u-nu-" selects a numbering system
+// other than the locale's default; for example, "hi-IN-u-nu-deva" uses
+// Devanagari digits. Only the digits are changed, not the separators.
+func Lookup(tag string) (Locale, bool) {
+	t := strings.ToLower(strings.Replace(tag, "_", "-", -1))
+
+	var zero rune
+	if i := strings.Index(t, "-u-"); i >= 0 {
+		ext := strings.Split(t[i+len("-u-"):], "-")
+		t = t[:i]
+		for j := 0; j+1 < len(ext); j++ {
+			if ext[j] == "nu" {
+				var ok bool
+				if zero, ok = numberingSystems[ext[j+1]]; !ok {
+					return Locale{}, false
+				}
+			}
+		}
+	}
+
+	l, ok := locales[t]
+	if !ok {
+		if i := strings.IndexByte(t, '-'); i >= 0 {
+			l, ok = locales[t[:i]]
+		}
+		if !ok {
+			return Locale{}, false
+		}
+	}
+	if zero != 0 {
+		l.Zero = zero
+	}
+	l.Tag = tag
+	return l, true
+}
+
+// MustLookup is like Lookup, but panics if tag isn't found.
+func MustLookup(tag string) Locale {
+	l, ok := Lookup(tag)
+	if !ok {
+		panic("locale: unknown locale " + tag)
+	}
+	return l
+}
+
+// Format returns x formatted without an exponent, like x.Text('f', -1), using
+// l's conventions.
+func (l Locale) Format(x *decimal.Big) string {
+	var buf [64]byte
+	return string(l.Append(buf[:0], x, 'f', -1))
+}
+
+// Append appends x formatted by x.Append with fmt and prec to buf using l's
+// conventions, and returns the extended buffer. The integer part is grouped
+// unless an exponent is used. Infinities and NaNs are written like Text,
+// except for their sign.
+func (l Locale) Append(buf []byte, x *decimal.Big, fmt byte, prec int) []byte {
+	var tmp [64]byte
+	s := x.Append(tmp[:0], fmt, prec)
+	if len(s) > 0 && s[0] == '%' {
+		// Invalid format.
+		return append(buf, s...)
+	}
+
+	switch {
+	case len(s) == 0:
+	case s[0] == '-':
+		buf = append(buf, l.minus()...)
+		s = s[1:]
+	case s[0] == '+':
+		buf = append(buf, l.plus()...)
+		s = s[1:]
+	}
+	if !x.IsFinite() {
+		return append(buf, s...)
+	}
+
+	// Integer part.
+	n := 0
+	for n < len(s) && isDigit(s[n]) {
+		n++
+	}
+	group := bytes.IndexAny(s, "eE") < 0
+	buf = l.appendInt(buf, s[:n], group)
+
+	for _, c := range s[n:] {
+		switch {
+		case isDigit(c):
+			buf = appendRune(buf, l.zero()+rune(c-'0'))
+		case c == '.':
+			buf = append(buf, l.decimal()...)
+		case c == 'e' || c == 'E':
+			buf = append(buf, l.exponent()...)
+		case c == '-':
+			buf = append(buf, l.minus()...)
+		case c == '+':
+			buf = append(buf, l.plus()...)
+		default:
+			buf = append(buf, c)
+		}
+	}
+	return buf
+}
+
+// appendInt appends the ASCII digits d in l's digits, grouped if group is
+// true.
+func (l Locale) appendInt(buf, d []byte, group bool) []byte {
+	p, q := l.PrimaryGroup, l.secondaryGroup()
+	group = group && p > 0 && len(d) >= p+l.minGrouping()
+	for i, c := range d {
+		// A separator goes before the digit if the number of digits after it
+		// completes the primary group and some number of secondary groups.
+		if k := len(d) - i; group && i > 0 && k >= p && (k-p)%q == 0 {
+			buf = append(buf, l.Group...)
+		}
+		buf = appendRune(buf, l.zero()+rune(c-'0'))
+	}
+	return buf
+}
+
+func appendRune(buf []byte, r rune) []byte {
+	if r < utf8.RuneSelf {
+		return append(buf, byte(r))
+	}
+	var tmp [utf8.UTFMax]byte
+	n := utf8.EncodeRune(tmp[:], r)
+	return append(buf, tmp[:n]...)
+}
+
+// Parse sets z to the value of s, written using l's conventions, and returns
+// z. Leading and trailing white space is ignored, and grouping separators are
+// optional, but must be in the right places if used. As well as l's digits,
+// signs, and exponent, ASCII digits, "-", "+", "E", and U+2212 (minus sign)
+// are accepted. If l's Group is a space, any white space is accepted in its
+// place, and if it's U+2019 (right single quotation mark), so is "'".
+// Infinities and NaNs are parsed like decimal.Parser.
+//
+// If s isn't valid, Parse returns nil and a *decimal.SyntaxError whose Offset
+// is the offset in s, and z is unchanged. Like SetString, the value isn't
+// rounded.
+func (l Locale) Parse(z *decimal.Big, s string) (*decimal.Big, error) {
+	var (
+		t    []byte // s in the form accepted by decimal.Parser
+		offs []int  // offset in s of each byte of t
+		g    grouping
+	)
+	emit := func(c byte, i int) {
+		t = append(t, c)
+		offs = append(offs, i)
+	}
+
+	start := len(s) - len(strings.TrimLeftFunc(s, unicode.IsSpace))
+	end := len(strings.TrimRightFunc(s, unicode.IsSpace))
+	if end < start {
+		end = start
+	}
+	for i := start; i < end; {
+		r, n := utf8.DecodeRuneInString(s[i:end])
+		switch {
+		case r == '\u061c' || r == '\u200e' || r == '\u200f':
+			// Bidirectional marks, like those in ar-EG's minus sign.
+		case r == '-' || r == '\u2212':
+			emit('-', i)
+		case r == '+':
+			emit('+', i)
+		case r >= '0' && r <= '9':
+			emit(byte(r), i)
+			g.digit()
+		case r >= l.zero() && r <= l.zero()+9:
+			emit(byte('0'+r-l.zero()), i)
+			g.digit()
+		case !g.closed && l.PrimaryGroup > 0 && l.isGroup(s[i:end], r):
+			if !g.sep(i) {
+				return nil, syntaxError(s, i, "misplaced grouping separator")
+			}
+			if strings.HasPrefix(s[i:], l.Group) {
+				n = len(l.Group)
+			}
+		case strings.HasPrefix(s[i:end], l.decimal()):
+			emit('.', i)
+			n = len(l.decimal())
+			g.end()
+		case strings.HasPrefix(s[i:end], l.exponent()):
+			emit('E', i)
+			n = len(l.exponent())
+			g.end()
+		case r == '.' || r == ',':
+			// Another locale's separators.
+			return nil, syntaxError(s, i, fmt.Sprintf("unexpected %q", r))
+		default:
+			for j := 0; j < n; j++ {
+				emit(s[i+j], i+j)
+			}
+			if g.digits() {
+				g.end()
+			}
+		}
+		if k := g.check(l); k >= 0 {
+			return nil, syntaxError(s, k, "misplaced grouping separator")
+		}
+		i += n
+	}
+	g.end()
+	if k := g.check(l); k >= 0 {
+		return nil, syntaxError(s, k, "misplaced grouping separator")
+	}
+
+	x, err := decimal.Parser{}.Parse(z, string(t))
+	if err != nil {
+		var se *decimal.SyntaxError
+		if !errors.As(err, &se) {
+			return nil, err
+		}
+		k, reason := end, se.Reason
+		if se.Offset < len(offs) {
+			k = offs[se.Offset]
+			if strings.HasPrefix(reason, "unexpected '") {
+				// The reason quotes t, so quote the character in s instead.
+				r, _ := utf8.DecodeRuneInString(s[k:end])
+				reason = fmt.Sprintf("unexpected %q", r)
+			}
+		}
+		return nil, syntaxError(s, k, reason)
+	}
+	return x, nil
+}
+
+func syntaxError(s string, i int, reason string) error {
+	return &decimal.SyntaxError{Input: s, Offset: i, Reason: reason}
+}
+
+// isGroup reports whether s, which starts with r, starts with a grouping
+// separator.
+func (l Locale) isGroup(s string, r rune) bool {
+	if l.Group == "" {
+		return false
+	}
+	if strings.HasPrefix(s, l.Group) {
+		return true
+	}
+	switch g, _ := utf8.DecodeRuneInString(l.Group); {
+	case unicode.IsSpace(g):
+		return unicode.IsSpace(r)
+	case g == '\u2019':
+		return r == '\''
+	}
+	return false
+}
+
+// grouping checks the positions of grouping separators in the integer part
+// of a number.
+type grouping struct {
+	groups  []int // number of digits in each group so far
+	seps    []int // offset of the separator before each group after the first
+	closed  bool  // the integer part has ended
+	checked bool  // check has been called since the integer part ended
+}
+
+func (g *grouping) digits() bool { return len(g.groups) > 0 }
+
+func (g *grouping) digit() {
+	if g.closed {
+		return
+	}
+	if len(g.groups) == 0 {
+		g.groups = append(g.groups, 0)
+	}
+	g.groups[len(g.groups)-1]++
+}
+
+// sep records a separator at the offset i, and reports whether it follows a
+// digit.
+func (g *grouping) sep(i int) bool {
+	if len(g.groups) == 0 || g.groups[len(g.groups)-1] == 0 {
+		return false
+	}
+	g.groups = append(g.groups, 0)
+	g.seps = append(g.seps, i)
+	return true
+}
+
+// end ends the integer part.
+func (g *grouping) end() { g.closed = true }
+
+// check returns the offset of the first misplaced separator, or -1, once the
+// integer part has ended.
+func (g *grouping) check(l Locale) int {
+	if !g.closed || g.checked {
+		return -1
+	}
+	g.checked = true
+	n := len(g.groups)
+	if n < 2 {
+		return -1
+	}
+	p, q := l.PrimaryGroup, l.secondaryGroup()
+	if g.groups[0] > q {
+		return g.seps[0]
+	}
+	for k := 1; k < n-1; k++ {
+		if g.groups[k] != q {
+			return g.seps[k-1]
+		}
+	}
+	if g.groups[n-1] != p {
+		return g.seps[n-2]
+	}
+	return -1
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
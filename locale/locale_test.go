@@ -0,0 +1,156 @@
+package locale_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/ericlagergren/decimal/locale"
+)
+
+func TestLocale_Format(t *testing.T) {
+	for i, test := range [...]struct {
+		tag  string
+		in   string
+		fmt  byte
+		prec int
+		want string
+	}{
+		{"en-US", "1234567.891", 'f', -1, "1,234,567.891"},
+		{"en", "123", 'f', -1, "123"},
+		{"en", "-1234", 'f', -1, "-1,234"},
+		{"de-DE", "1234.56", 'f', -1, "1.234,56"},
+		{"de_de", "-0.5", 'f', -1, "-0,5"},
+		{"fr-CH", "1234.56", 'f', -1, "1’234.56"},
+		{"fr", "1234567.5", 'f', -1, "1 234 567,5"},
+		{"hi-IN", "123456.78", 'f', -1, "1,23,456.78"},
+		{"hi-IN", "12345678", 'f', -1, "1,23,45,678"},
+		{"hi-IN-u-nu-deva", "1234.5", 'f', -1, "१,२३४.५"},
+		{"ar-EG", "-1234.56", 'f', -1, "؜-١٬٢٣٤٫٥٦"},
+		{"ar-EG", "1.5E+10", 'e', -1, "١٫٥أس؜+١٠"},
+		{"es", "1234", 'f', -1, "1234"},
+		{"es", "12345", 'f', -1, "12.345"},
+		{"sv", "-1234.5", 'f', 2, "−1 234,50"},
+		{"de", "1234567", 'e', 3, "1,23E+6"},
+		{"de", "-Inf", 's', -1, "-Infinity"},
+		{"en", "1", 'x', -1, "%x"},
+	} {
+		l, ok := locale.Lookup(test.tag)
+		if !ok {
+			t.Fatalf("#%d: Lookup(%q) failed", i, test.tag)
+		}
+		x, _ := new(decimal.Big).SetString(test.in)
+		if got := string(l.Append(nil, x, test.fmt, test.prec)); got != test.want {
+			t.Fatalf("#%d: %s: Append(%s, %q, %d): got %q, wanted %q",
+				i, test.tag, test.in, test.fmt, test.prec, got, test.want)
+		}
+		if test.fmt == 'f' && test.prec < 0 {
+			if got := l.Format(x); got != test.want {
+				t.Fatalf("#%d: %s: Format(%s): got %q, wanted %q", i, test.tag, test.in, got, test.want)
+			}
+		}
+	}
+}
+
+func TestLocale_Parse(t *testing.T) {
+	for i, test := range [...]struct {
+		tag    string
+		in     string
+		want   string // if valid
+		offset int    // if invalid
+		reason string // if invalid
+	}{
+		{tag: "de-DE", in: "1.234,56", want: "1234.56"},
+		{tag: "de-DE", in: "1234,56", want: "1234.56"},
+		{tag: "de-DE", in: " -0,5 ", want: "-0.5"},
+		{tag: "fr-CH", in: "1’234.56", want: "1234.56"},
+		{tag: "fr-CH", in: "1'234'567", want: "1234567"},
+		{tag: "fr", in: "1 234,5", want: "1234.5"},
+		{tag: "fr", in: "1 234,5", want: "1234.5"},
+		{tag: "hi-IN", in: "1,23,456.78", want: "123456.78"},
+		{tag: "ar-EG", in: "١٬٢٣٤٫٥٦", want: "1234.56"},
+		{tag: "ar-EG", in: "؜-١٢", want: "-12"},
+		{tag: "ar-EG", in: "١أس٣", want: "1E+3"},
+		{tag: "sv", in: "−1,5", want: "-1.5"},
+		{tag: "en", in: "1.5e3", want: "1.5E+3"},
+		{tag: "en", in: "-Infinity", want: "-Infinity"},
+
+		{tag: "de-DE", in: "1.5", offset: 1, reason: "misplaced grouping separator"},
+		{tag: "de-DE", in: "1234.567", offset: 4, reason: "misplaced grouping separator"},
+		{tag: "de-DE", in: ".123", offset: 0, reason: "misplaced grouping separator"},
+		{tag: "de-DE", in: "1..234", offset: 2, reason: "misplaced grouping separator"},
+		{tag: "de-DE", in: "1,234.5", offset: 5, reason: "unexpected '.'"},
+		{tag: "en", in: "1,234,56", offset: 5, reason: "misplaced grouping separator"},
+		{tag: "en", in: "1,234.5,6", offset: 7, reason: "unexpected ','"},
+		{tag: "hi-IN", in: "123,456", offset: 3, reason: "misplaced grouping separator"},
+		{tag: "hi-IN", in: "1,2,456", offset: 1, reason: "misplaced grouping separator"},
+		{tag: "fr", in: "1,5.", offset: 3, reason: "unexpected '.'"},
+		{tag: "de-DE", in: "1,2,3", offset: 3, reason: "unexpected ','"},
+		{tag: "ar-EG", in: "١٫٢٫٣", offset: 6, reason: "unexpected '٫'"},
+		{tag: "en", in: "12$", offset: 2, reason: "unexpected '$'"},
+		{tag: "ar-EG", in: "١x", offset: 2, reason: "unexpected 'x'"},
+		{tag: "en", in: "  ", offset: 2, reason: "empty string"},
+		{tag: "en", in: "-", offset: 1, reason: "missing digits"},
+	} {
+		l := locale.MustLookup(test.tag)
+		z := decimal.New(42, 0)
+		x, err := l.Parse(z, test.in)
+		if test.reason == "" {
+			if err != nil {
+				t.Fatalf("#%d: %s: Parse(%q): unexpected error: %v", i, test.tag, test.in, err)
+			}
+			if x != z || x.String() != test.want {
+				t.Fatalf("#%d: %s: Parse(%q): got %s, wanted %s", i, test.tag, test.in, x, test.want)
+			}
+			continue
+		}
+
+		var se *decimal.SyntaxError
+		if !errors.As(err, &se) {
+			t.Fatalf("#%d: %s: Parse(%q): wanted *SyntaxError, got %v", i, test.tag, test.in, err)
+		}
+		if se.Offset != test.offset || se.Reason != test.reason || se.Input != test.in {
+			t.Fatalf("#%d: %s: Parse(%q): got %q at %d, wanted %q at %d",
+				i, test.tag, test.in, se.Reason, se.Offset, test.reason, test.offset)
+		}
+		if x != nil || z.Cmp(decimal.New(42, 0)) != 0 {
+			t.Fatalf("#%d: %s: Parse(%q): z was modified", i, test.tag, test.in)
+		}
+	}
+}
+
+// TestLocale_RoundTrip checks that Parse accepts what Format produces.
+func TestLocale_RoundTrip(t *testing.T) {
+	inputs := [...]string{"0", "-1", "999", "1000", "-12345.678", "123456789012345678901234567890.5", "0.000001"}
+	for _, tag := range [...]string{"en", "de", "de-CH", "fr", "fr-CH", "es", "pl", "hi-IN", "bn", "ar-EG", "fa", "sv", "he"} {
+		l := locale.MustLookup(tag)
+		for _, s := range inputs {
+			x, _ := new(decimal.Big).SetString(s)
+			f := l.Format(x)
+			y, err := l.Parse(new(decimal.Big), f)
+			if err != nil {
+				t.Fatalf("%s: Parse(Format(%s) = %q): %v", tag, s, f, err)
+			}
+			if y.Cmp(x) != 0 {
+				t.Fatalf("%s: Parse(Format(%s) = %q) = %s", tag, s, f, y)
+			}
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for _, tag := range [...]string{"de-LU", "DE", "pt_BR", "ar-EG-u-nu-latn"} {
+		l, ok := locale.Lookup(tag)
+		if !ok || l.Tag != tag {
+			t.Fatalf("Lookup(%q): got %+v, %t", tag, l, ok)
+		}
+	}
+	if l := locale.MustLookup("ar-EG-u-nu-latn"); l.Zero != '0' {
+		t.Fatalf("ar-EG-u-nu-latn: got zero %q", l.Zero)
+	}
+	for _, tag := range [...]string{"", "xx", "en-u-nu-klingon"} {
+		if _, ok := locale.Lookup(tag); ok {
+			t.Fatalf("Lookup(%q) should fail", tag)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package locale
+
+// numberingSystems maps CLDR numbering systems to their digit zero.
+var numberingSystems = map[string]rune{
+	"arab":     '\u0660', // Arabic-Indic
+	"arabext":  '\u06f0', // Extended Arabic-Indic
+	"beng":     '\u09e6', // Bengali
+	"deva":     '\u0966', // Devanagari
+	"fullwide": '\uff10', // Full-width
+	"gujr":     '\u0ae6', // Gujarati
+	"guru":     '\u0a66', // Gurmukhi
+	"knda":     '\u0ce6', // Kannada
+	"latn":     '0',      // ASCII
+	"mlym":     '\u0d66', // Malayalam
+	"mymr":     '\u1040', // Myanmar
+	"tamldec":  '\u0be6', // Tamil
+	"telu":     '\u0c66', // Telugu
+	"thai":     '\u0e50', // Thai
+}
+
+// Common separators.
+const (
+	nbsp   = "\u00a0" // no-break space
+	nnbsp  = "\u202f" // narrow no-break space
+	apos   = "\u2019" // right single quotation mark
+	minus  = "\u2212" // minus sign
+	lrm    = "\u200e" // left-to-right mark
+	alm    = "\u061c" // Arabic letter mark
+	arDec  = "\u066b" // Arabic decimal separator
+	arThou = "\u066c" // Arabic thousands separator
+)
+
+// latn returns a Locale with ASCII digits, groups of three, and the given
+// separators.
+func latn(dec, group string) Locale {
+	return Locale{Decimal: dec, Group: group, PrimaryGroup: 3}
+}
+
+// indian returns a Locale with ASCII digits and the Indian grouping, e.g.
+// 1,23,45,678.9.
+func indian() Locale {
+	return Locale{Decimal: ".", Group: ",", PrimaryGroup: 3, SecondaryGroup: 2}
+}
+
+// with returns l after applying fn to it.
+func with(l Locale, fn func(l *Locale)) Locale {
+	fn(&l)
+	return l
+}
+
+// locales are the default conventions of common locales from CLDR, keyed by
+// lowercase language tag. The Swiss locales use the apostrophe and "." of
+// everyday Swiss usage.
+var locales = map[string]Locale{
+	"ar": with(latn(arDec, arThou), func(l *Locale) {
+		l.Minus, l.Plus = alm+"-", alm+"+"
+		l.Exponent = "\u0623\u0633"
+		l.Zero = '\u0660'
+	}),
+	"ar-ae": with(latn(".", ","), func(l *Locale) { l.Minus, l.Plus = lrm+"-", lrm+"+" }),
+	"ar-dz": with(latn(",", "."), func(l *Locale) { l.Minus, l.Plus = lrm+"-", lrm+"+" }),
+	"ar-ma": with(latn(",", "."), func(l *Locale) { l.Minus, l.Plus = lrm+"-", lrm+"+" }),
+	"ar-tn": with(latn(",", "."), func(l *Locale) { l.Minus, l.Plus = lrm+"-", lrm+"+" }),
+	"bn":    with(indian(), func(l *Locale) { l.Zero = '\u09e6' }),
+	"cs":    latn(",", nbsp),
+	"da":    latn(",", "."),
+	"de":    latn(",", "."),
+	"de-at": latn(",", nbsp),
+	"de-ch": latn(".", apos),
+	"de-li": latn(".", apos),
+	"el":    latn(",", "."),
+	"en":    latn(".", ","),
+	"en-in": indian(),
+	"en-za": latn(",", nbsp),
+	"es":    with(latn(",", "."), func(l *Locale) { l.MinGrouping = 2 }),
+	"es-mx": latn(".", ","),
+	"es-us": latn(".", ","),
+	"fa": with(latn(arDec, arThou), func(l *Locale) {
+		l.Minus, l.Plus = lrm+minus, lrm+"+"
+		l.Exponent = "\u00d7\u06f1\u06f0^"
+		l.Zero = '\u06f0'
+	}),
+	"fi":    with(latn(",", nbsp), func(l *Locale) { l.Minus = minus }),
+	"fr":    latn(",", nnbsp),
+	"fr-ca": latn(",", nbsp),
+	"fr-ch": latn(".", apos),
+	"he":    with(latn(".", ","), func(l *Locale) { l.Minus, l.Plus = lrm+"-", lrm+"+" }),
+	"hi":    indian(),
+	"hu":    latn(",", nbsp),
+	"id":    latn(",", "."),
+	"it":    latn(",", "."),
+	"it-ch": latn(".", apos),
+	"ja":    latn(".", ","),
+	"ko":    latn(".", ","),
+	"mr":    with(indian(), func(l *Locale) { l.Zero = '\u0966' }),
+	"nb":    with(latn(",", nbsp), func(l *Locale) { l.Minus = minus }),
+	"nl":    latn(",", "."),
+	"no":    with(latn(",", nbsp), func(l *Locale) { l.Minus = minus }),
+	"pl":    with(latn(",", nbsp), func(l *Locale) { l.MinGrouping = 2 }),
+	"pt":    latn(",", "."),
+	"pt-pt": with(latn(",", nbsp), func(l *Locale) { l.MinGrouping = 2 }),
+	"ro":    latn(",", "."),
+	"ru":    latn(",", nbsp),
+	"sv":    with(latn(",", nbsp), func(l *Locale) { l.Minus = minus }),
+	"th":    latn(".", ","),
+	"tr":    latn(",", "."),
+	"uk":    latn(",", nbsp),
+	"vi":    latn(",", "."),
+	"zh":    latn(".", ","),
+}
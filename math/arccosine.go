@@ -17,6 +17,11 @@ import (
 //     Acos(-1)   = pi
 //     Acos(1)    = 0
 func Acos(z, x *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Acos", func() { Acos(z, x) }, z, x)
+		return z
+	}
+
 	if z.CheckNaNs(x, nil) {
 		return z
 	}
@@ -17,6 +17,11 @@ import (
 //		Asin(x)    = NaN if x < -1 or x > 1
 //		Asin(±1)   = ±pi/2
 func Asin(z, x *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Asin", func() { Asin(z, x) }, z, x)
+		return z
+	}
+
 	if z.CheckNaNs(x, nil) {
 		return z
 	}
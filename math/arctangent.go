@@ -136,6 +136,11 @@ func getAtanQ(ctx decimal.Context, x2p1 *decimal.Big) SplitFunc {
 //		Atan(NaN)  = NaN
 //		Atan(±Inf) = ±x * pi/2
 func Atan(z, x *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Atan", func() { Atan(z, x) }, z, x)
+		return z
+	}
+
 	if z.CheckNaNs(x, nil) {
 		return z
 	}
@@ -206,6 +211,11 @@ func Atan(z, x *decimal.Big) *decimal.Big {
 //     Atan2(y >= 0, x < 0) = Atan(y/x) + pi
 //     Atan2(y < 0, x < 0)  = Atan(y/x) - pi
 func Atan2(z, y, x *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Atan2", func() { Atan2(z, y, x) }, z, y, x)
+		return z
+	}
+
 	if z.CheckNaNs(y, x) {
 		return z
 	}
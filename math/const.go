@@ -34,6 +34,11 @@ var (
 
 // E sets z to the mathematical constant e and returns z.
 func E(z *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("E", func() { E(z) }, z)
+		return z
+	}
+
 	ctx := decimal.Context{Precision: precision(z)}
 	if ctx.Precision <= constPrec {
 		return ctx.Set(z, _E)
@@ -72,6 +77,10 @@ func pi2(z *decimal.Big, ctx decimal.Context) *decimal.Big {
 
 // Pi sets z to the mathematical constant pi and returns z.
 func Pi(z *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Pi", func() { Pi(z) }, z)
+		return z
+	}
 	return pi(z, decimal.Context{Precision: precision(z)})
 }
 
@@ -67,6 +67,11 @@ func (w walliser) Wallis() (a, a1, b, b1, p, eps *decimal.Big) {
 // using the recurrence algorithm discovered by John Wallis. For more information
 // on continued fraction representations, see the Lentz function.
 func Wallis(z *decimal.Big, g Generator) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Wallis", func() { Wallis(z, g) }, z)
+		return z
+	}
+
 	if !g.Next() {
 		return z
 	}
@@ -168,6 +173,11 @@ var tiny = decimal.New(10, 60)
 // the Generator to implement the Lentzer interface and set a higher precision
 // for f, Δ, C, and D.
 func Lentz(z *decimal.Big, g Generator) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Lentz", func() { Lentz(z, g) }, z)
+		return z
+	}
+
 	// We use the modified Lentz algorithm from
 	// "Numerical Recipes in C: The Art of Scientific Computing" (ISBN
 	// 0-521-43105-5), pg 171.
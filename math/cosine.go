@@ -135,6 +135,11 @@ func getCosineQ(ctx decimal.Context) func(n uint64) *decimal.Big {
 //		Cos(NaN)  = NaN
 //		Cos(±Inf) = NaN
 func Cos(z, x *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Cos", func() { Cos(z, x) }, z, x)
+		return z
+	}
+
 	if z.CheckNaNs(x, nil) {
 		return z
 	}
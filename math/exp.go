@@ -7,6 +7,11 @@ import (
 
 // Exp sets z to e ** x and returns z.
 func Exp(z, x *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Exp", func() { Exp(z, x) }, z, x)
+		return z
+	}
+
 	if z.CheckNaNs(x, nil) {
 		return z
 	}
@@ -8,6 +8,11 @@ import (
 // Floor sets z to the greatest integer value less than or equal to x and returns
 // z.
 func Floor(z, x *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Floor", func() { Floor(z, x) }, z, x)
+		return z
+	}
+
 	if z.CheckNaNs(x, nil) {
 		return z
 	}
@@ -19,6 +24,11 @@ func Floor(z, x *decimal.Big) *decimal.Big {
 // Ceil sets z to the least integer value greater than or equal to x and returns
 // z.
 func Ceil(z, x *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Ceil", func() { Ceil(z, x) }, z, x)
+		return z
+	}
+
 	// ceil(x) = -floor(-x)
 	return z.Neg(Floor(z, misc.CopyNeg(z, x)))
 }
@@ -8,6 +8,11 @@ import (
 
 // Log10 sets z to the common logarithm of x and returns z.
 func Log10(z, x *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Log10", func() { Log10(z, x) }, z, x)
+		return z
+	}
+
 	if logSpecials(z, x) {
 		return z
 	}
@@ -28,6 +33,11 @@ func Log10(z, x *decimal.Big) *decimal.Big {
 
 // Log sets z to the natural logarithm of x and returns z.
 func Log(z, x *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Log", func() { Log(z, x) }, z, x)
+		return z
+	}
+
 	if logSpecials(z, x) {
 		return z
 	}
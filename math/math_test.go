@@ -3,7 +3,9 @@ package math_test
 import (
 	"testing"
 
+	"github.com/ericlagergren/decimal"
 	"github.com/ericlagergren/decimal/internal/test"
+	"github.com/ericlagergren/decimal/math"
 )
 
 func TestExp(t *testing.T)   { test.Exp.Test(t) }
@@ -11,3 +13,69 @@ func TestLog(t *testing.T)   { test.Log.Test(t) }
 func TestLog10(t *testing.T) { test.Log10.Test(t) }
 func TestPow(t *testing.T)   { test.Pow.Test(t) }
 func TestSqrt(t *testing.T)  { test.Sqrt.Test(t) }
+
+func TestPow_MaxDigits(t *testing.T) {
+	for i, test := range [...]struct {
+		x, y   string
+		prec   int
+		digits int
+		r      string
+	}{
+		0:  {"2", "10", 16, 100, "1024"},
+		1:  {"2", "1000", 16, 20, "1.071508607186267E+301"},
+		2:  {"2", "1000", 16, 100, "1.071508607186267E+301"},
+		3:  {"2", "1E+1000", 16, 100, "Infinity"},
+		4:  {"2", "0.5", 16, 100, "1.414213562373095"},
+		5:  {"2", "0.5", 200, 100, "NaN"},
+		6:  {"2", "10", 200, 100, "1024"},
+		7:  {"2", "3", decimal.UnlimitedPrecision, 1000, "8"},
+		8:  {"2", "-3", decimal.UnlimitedPrecision, 1000, "0.125"},
+		9:  {"2", "1000", decimal.UnlimitedPrecision, 100, "NaN"},
+		10: {"2", "-1000", decimal.UnlimitedPrecision, 100, "NaN"},
+		11: {"2", "0.5", decimal.UnlimitedPrecision, 1000, "NaN"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		y, _ := new(decimal.Big).SetString(test.y)
+		z := decimal.WithContext(decimal.Context{
+			Precision:     test.prec,
+			OperatingMode: decimal.GDA,
+			Options:       &decimal.Options{MaxDigits: test.digits},
+		})
+		math.Pow(z, x, y)
+		if s := z.String(); s != test.r {
+			t.Fatalf("#%d: Pow(%s, %s): wanted %q, got %q", i, x, y, test.r, s)
+		}
+		if (test.r == "NaN") != (z.Context.Conditions&decimal.InsufficientStorage != 0) {
+			t.Fatalf("#%d: Pow(%s, %s): unexpected conditions %s", i, x, y, z.Context.Conditions)
+		}
+	}
+}
+
+func TestHook(t *testing.T) {
+	var r decimal.Recorder
+	ctx := decimal.Context{Precision: 5, Options: &decimal.Options{Hook: &r}}
+
+	z := decimal.WithContext(ctx)
+	math.Sqrt(z, decimal.New(2, 0))
+	math.Pow(z, decimal.New(2, 0), decimal.New(5, 1))
+	math.Exp(z, decimal.New(0, 0)) // exact, so not recorded
+	math.Pi(z)
+
+	want := []string{
+		"Sqrt(2) = 1.4142 [5, ToNearestEven]: inexact, rounded",
+		"Pow(2, 0.5) = 1.4142 [5, ToNearestEven]: inexact, rounded",
+		"Pi() = 3.1416 [5, ToNearestEven]: inexact, rounded",
+	}
+	events := r.Events()
+	if len(events) != len(want) {
+		t.Fatalf("wanted %d events, got %d: %v", len(want), len(events), events)
+	}
+	for i, e := range events {
+		if s := e.String(); s != want[i] {
+			t.Fatalf("#%d: wanted %q, got %q", i, want[i], s)
+		}
+	}
+	if z.Context.Options.Hook != &r {
+		t.Fatal("z's Hook wasn't restored")
+	}
+}
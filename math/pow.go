@@ -9,6 +9,11 @@ import (
 
 // Pow sets z to x**y and returns z.
 func Pow(z, x, y *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Pow", func() { Pow(z, x, y) }, z, x, y)
+		return z
+	}
+
 	if z.CheckNaNs(x, y) {
 		return z
 	}
@@ -67,12 +72,26 @@ func Pow(z, x, y *decimal.Big) *decimal.Big {
 
 func powInt(z, x, y *decimal.Big) *decimal.Big {
 	prec := precision(z)
-	ctx := decimal.Context{Precision: prec - y.Scale() + y.Precision() + 2}
+	if tooManyDigits(z, powIntDigits(x, y, prec)) {
+		return z
+	}
+
+	ctx := decimal.Context{Precision: decimal.UnlimitedPrecision}
+	if prec != decimal.UnlimitedPrecision {
+		ctx.Precision = prec - y.Scale() + y.Precision() + 2
+	}
 
 	var x0 decimal.Big
 	if y.Signbit() {
-		ctx.Precision++
+		if prec != decimal.UnlimitedPrecision {
+			ctx.Precision++
+		}
 		ctx.Quo(&x0, one, x)
+		if x0.IsNaN(0) {
+			// 1/x doesn't terminate.
+			z.Context.Conditions |= x0.Context.Conditions
+			return z.SetNaN(false)
+		}
 	} else {
 		x0.Copy(x)
 	}
@@ -129,6 +148,28 @@ func powInt(z, x, y *decimal.Big) *decimal.Big {
 	return ctx.Round(z)
 }
 
+// powIntDigits returns an upper bound on the number of digits in x**y, where
+// y is an integer, rounded to prec digits.
+func powIntDigits(x, y *decimal.Big, prec int) int {
+	// x**y has at most |y| times as many digits as x. 1/x**y only terminates
+	// if x**y's coefficient is a product of 2s and 5s, in which case it has at
+	// most log(5)/log(2) times as many digits as x**y.
+	yy, ok := y.Int64()
+	if !ok {
+		return prec
+	}
+	n := uint64(x.Precision())
+	ay := uint64(yy)
+	if yy < 0 {
+		ay = -ay
+		n *= 3
+	}
+	if ay > uint64(prec)/n {
+		return prec
+	}
+	return int(n * ay)
+}
+
 func powDec(z, x, y *decimal.Big) *decimal.Big {
 	if z == y {
 		y = new(decimal.Big).Copy(y)
@@ -138,10 +179,13 @@ func powDec(z, x, y *decimal.Big) *decimal.Big {
 		x = misc.CopyAbs(new(decimal.Big), x)
 	}
 
-	oc := z.Context
-	z.Context = decimal.Context{
-		Precision: max(x.Precision(), precision(z)) + 4 + 19,
+	// x**y is rounded to z's precision, and is inexact unless y is an integer.
+	if tooManyDigits(z, precision(z)) {
+		return z
 	}
+	prec := max(x.Precision(), precision(z)) + 4 + 19
+	oc := z.Context
+	z.Context = decimal.Context{Precision: prec}
 	Exp(z, z.Mul(y, Log(z, x)))
 	if neg && z.IsFinite() {
 		misc.CopyNeg(z, z)
@@ -12,6 +12,11 @@ import "github.com/ericlagergren/decimal"
 //     Sin(NaN) = NaN
 //     Sin(Inf) = NaN
 func Sin(z, x *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Sin", func() { Sin(z, x) }, z, x)
+		return z
+	}
+
 	if z.CheckNaNs(x, nil) {
 		return z
 	}
@@ -6,6 +6,11 @@ import (
 
 // Hypot sets z to Sqrt(p*p + q*q) and returns z.
 func Hypot(z, p, q *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Hypot", func() { Hypot(z, p, q) }, z, p, q)
+		return z
+	}
+
 	if z.CheckNaNs(p, q) {
 		return z
 	}
@@ -34,6 +39,11 @@ var (
 
 // Sqrt sets z to the square root of x and returns z.
 func Sqrt(z, x *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Sqrt", func() { Sqrt(z, x) }, z, x)
+		return z
+	}
+
 	if z.CheckNaNs(x, nil) {
 		return z
 	}
@@ -36,6 +36,20 @@ got:    %q
 	}
 }
 
+func TestSqrt_DefaultContext(t *testing.T) {
+	defer decimal.SetDefaultContext(decimal.Context128)()
+
+	// A zero-valued Big uses the default precision without keeping it.
+	var z decimal.Big
+	Sqrt(&z, decimal.New(2, 0))
+	if s, want := z.String(), "1.414213562373095048801688724209698"; s != want {
+		t.Fatalf("wanted %q, got %q", want, s)
+	}
+	if p := z.Context.Precision; p != 0 {
+		t.Fatalf("wanted a zero precision, got %d", p)
+	}
+}
+
 func TestIssue69(t *testing.T) {
 	x := new(decimal.Big)
 	maxSqrt := uint64(4294967295)
@@ -61,6 +61,11 @@ func prepTan(z, x *decimal.Big, ctx decimal.Context) (*decimal.Big, bool) {
 //     Tan(NaN) = NaN
 //     Tan(±Inf) = NaN
 func Tan(z, x *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Tan", func() { Tan(z, x) }, z, x)
+		return z
+	}
+
 	if z.CheckNaNs(x, nil) {
 		return z
 	}
@@ -30,33 +30,44 @@ func alias(a, b *decimal.Big) *decimal.Big {
 	return decimal.WithContext(a.Context)
 }
 
-func precision(z *decimal.Big) (p int) {
-	p = z.Context.Precision
+func precision(z *decimal.Big) int {
+	p := z.Context.Precision
 	if p > 0 && p <= decimal.UnlimitedPrecision {
 		return p
 	}
-	if p == 0 {
-		z.Context.Precision = decimal.DefaultPrecision
-	} else {
+	if p != 0 {
 		z.Context.Conditions |= decimal.InvalidContext
 	}
+	// Like the decimal package, a zero precision means the default Context's.
+	if p = decimal.DefaultContext().Precision; p != 0 {
+		return p
+	}
 	return decimal.DefaultPrecision
 }
 
-func maxscl(x *decimal.Big) int {
-	if x.Context.MaxScale != 0 {
-		return x.Context.MaxScale
+// tooManyDigits reports whether a result of n digits exceeds z's MaxDigits. If
+// so, it sets z to NaN and raises InsufficientStorage.
+func tooManyDigits(z *decimal.Big, n int) bool {
+	o := z.Context.Options
+	if o == nil || o.MaxDigits <= 0 || n <= o.MaxDigits {
+		return false
 	}
-	return decimal.MaxScale
+	z.Context.Conditions |= decimal.InsufficientStorage
+	z.SetNaN(false)
+	return true
 }
 
-func minscl(x *decimal.Big) int {
-	if x.Context.MinScale != 0 {
-		return x.Context.MinScale
-	}
-	return decimal.MinScale
+// hooked reports whether z's Context has a Hook, in which case the operation
+// setting z should be run under decimal.Watch.
+func hooked(z *decimal.Big) bool {
+	o := z.Context.Options
+	return o != nil && o.Hook != nil
 }
 
+func maxscl(x *decimal.Big) int { return x.Context.MaxScale() }
+
+func minscl(x *decimal.Big) int { return x.Context.MinScale() }
+
 func etiny(z *decimal.Big) int    { return minscl(z) - (precision(z) - 1) }
 func adjusted(x *decimal.Big) int { return (-x.Scale() + x.Precision()) - 1 }
 
@@ -3,6 +3,7 @@ package misc
 
 import (
 	"math/big"
+	"strconv"
 
 	"github.com/ericlagergren/decimal"
 	"github.com/ericlagergren/decimal/internal/arith"
@@ -14,19 +15,16 @@ var (
 	neg = decimal.New(-1, 0)
 )
 
-func maxscl(x *decimal.Big) int {
-	if x.Context.MaxScale != 0 {
-		return x.Context.MaxScale
-	}
-	return decimal.MaxScale
+// hooked reports whether z's Context has a Hook, in which case the operation
+// setting z should be run under decimal.Watch.
+func hooked(z *decimal.Big) bool {
+	o := z.Context.Options
+	return o != nil && o.Hook != nil
 }
 
-func minscl(x *decimal.Big) int {
-	if x.Context.MinScale != 0 {
-		return x.Context.MinScale
-	}
-	return decimal.MinScale
-}
+func maxscl(x *decimal.Big) int { return x.Context.MaxScale() }
+
+func minscl(x *decimal.Big) int { return x.Context.MinScale() }
 
 func etiny(z *decimal.Big) int { return minscl(z) - (precision(z) - 1) }
 func etop(z *decimal.Big) int  { return maxscl(z) - (precision(z) - 1) }
@@ -43,20 +41,124 @@ const (
 // canonical, it's identical to Copy.
 func Canonical(z, x *decimal.Big) *decimal.Big { return z.Copy(x) }
 
-// TODO(eric): do these...
-//
 // And sets z to the digit-wise logical ``and'' of x and y and returns z.
-// func And(z, x, y *Big) *Big
-//
-// Invert sets z to the digit-wise logical ``inversion'' of x and returns z.
-// func Invert(z, x *Big) *Big
-//
-// Or sets z to the digit-wise logical ``or'' of x and y and returns z.
-// func Or(z, x, y *Big) *Big
 //
+// x and y must be logical operands: finite, non-negative integers with a
+// scale of zero and only 0 or 1 as digits. Otherwise, z is set to NaN and
+// InvalidOperation is raised. Operands with more digits than z's precision
+// lose their most significant digits.
+func And(z, x, y *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("And", func() { And(z, x, y) }, z, x, y)
+		return z
+	}
+	return logical(z, x, y, func(a, b byte) byte { return a & b })
+}
+
+// Invert sets z to the digit-wise logical ``inversion'' of x, padded on the
+// left with zeros to z's precision, and returns z. See And for the
+// requirements on x. If z's precision is decimal.UnlimitedPrecision, only the
+// digits of x are inverted.
+func Invert(z, x *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Invert", func() { Invert(z, x) }, z, x)
+		return z
+	}
+	return logical(z, x, nil, func(a, _ byte) byte { return a ^ 1 })
+}
+
+// Or sets z to the digit-wise logical ``or'' of x and y and returns z. See And
+// for the requirements on x and y.
+func Or(z, x, y *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Or", func() { Or(z, x, y) }, z, x, y)
+		return z
+	}
+	return logical(z, x, y, func(a, b byte) byte { return a | b })
+}
+
 // Xor sets z to the digit-wise logical ``exclusive or'' of x and y and returns
-// z.
-// func Xor(z, x, y *Big) *Big
+// z. See And for the requirements on x and y.
+func Xor(z, x, y *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Xor", func() { Xor(z, x, y) }, z, x, y)
+		return z
+	}
+	return logical(z, x, y, func(a, b byte) byte { return a ^ b })
+}
+
+// logical sets z to the result of applying op to each pair of digits in x and
+// y, which must be logical operands. y may be nil.
+func logical(z, x, y *decimal.Big, op func(a, b byte) byte) *decimal.Big {
+	xd, ok := logicalDigits(x)
+	if !ok {
+		z.Context.Conditions |= decimal.InvalidOperation
+		return z.SetNaN(false)
+	}
+	var yd string
+	if y != nil {
+		if yd, ok = logicalDigits(y); !ok {
+			z.Context.Conditions |= decimal.InvalidOperation
+			return z.SetNaN(false)
+		}
+	}
+
+	n := precision(z)
+	if n == decimal.UnlimitedPrecision {
+		n = len(xd)
+		if len(yd) > n {
+			n = len(yd)
+		}
+	}
+	b := make([]byte, n)
+	for i := range b {
+		b[n-1-i] = '0' + op(digitAt(xd, i), digitAt(yd, i))
+	}
+	return setDigits(z, b, 0, false)
+}
+
+// logicalDigits returns the digits of x and whether x is a logical operand.
+func logicalDigits(x *decimal.Big) (string, bool) {
+	if !x.IsFinite() || x.Signbit() || x.Scale() != 0 {
+		return "", false
+	}
+	s := digits(x)
+	for i := 0; i < len(s); i++ {
+		if s[i] != '0' && s[i] != '1' {
+			return "", false
+		}
+	}
+	return s, true
+}
+
+// digitAt returns the value of the ith least significant digit in s, or zero
+// if s has fewer than i+1 digits.
+func digitAt(s string, i int) byte {
+	if i >= len(s) {
+		return 0
+	}
+	return s[len(s)-1-i] - '0'
+}
+
+// digits returns the digits of x's coefficient. x must be finite.
+func digits(x *decimal.Big) string {
+	xc, xb := decimal.Raw(x)
+	if *xc != c.Inflated {
+		return strconv.FormatUint(*xc, 10)
+	}
+	return xb.String()
+}
+
+// setDigits sets z to the decimal digits in b with the provided scale and sign
+// and returns z.
+func setDigits(z *decimal.Big, b []byte, scale int, neg bool) *decimal.Big {
+	var m big.Int
+	if _, ok := m.SetString(string(b), 10); !ok {
+		m.SetUint64(0)
+	}
+	z.SetBigMantScale(&m, scale)
+	return SetSignbit(z, neg)
+}
 
 // CmpTotal compares x and y in a manner similar to the Big.Cmp, but allows
 // ordering of all abstract representations. In particular, this means NaN
@@ -179,6 +281,30 @@ func MinAbs(x ...*decimal.Big) *decimal.Big {
 	return m
 }
 
+// Logb sets z to the adjusted exponent of x, the exponent x would have in
+// scientific notation, rounded to z's precision and returns z. If x is zero z
+// is set to -Inf and DivisionByZero is raised. If x is an infinity z is set to
+// +Inf.
+func Logb(z, x *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Logb", func() { Logb(z, x) }, z, x)
+		return z
+	}
+
+	if z.CheckNaNs(x, nil) {
+		return z
+	}
+	if x.IsInf(0) {
+		return z.SetInf(false)
+	}
+	if x.Sign() == 0 {
+		z.Context.Conditions |= decimal.DivisionByZero
+		return z.SetInf(true)
+	}
+	adj := int64(x.Precision()) - int64(x.Scale()) - 1
+	return z.Context.Round(z.SetMantScale(adj, 0))
+}
+
 // maxfor sets z to 999...N with the provided sign.
 func maxfor(z *big.Int, n, sign int) {
 	arith.Sub(z, arith.BigPow10(uint64(n)), 1)
@@ -191,6 +317,11 @@ func maxfor(z *big.Int, n, sign int) {
 // and returns z. If x is negative infinity the result will be negative infinity.
 // If the result is zero its sign will be negative and its scale will be MinScale.
 func NextMinus(z, x *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("NextMinus", func() { NextMinus(z, x) }, z, x)
+		return z
+	}
+
 	if z.CheckNaNs(x, nil) {
 		return z
 	}
@@ -216,6 +347,11 @@ func NextMinus(z, x *decimal.Big) *decimal.Big {
 // returns z. If x is positive infinity the result will be positive infinity. If
 // the result is zero it will be positive and its scale will be MaxScale.
 func NextPlus(z, x *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("NextPlus", func() { NextPlus(z, x) }, z, x)
+		return z
+	}
+
 	if z.CheckNaNs(x, nil) {
 		return z
 	}
@@ -237,6 +373,43 @@ func NextPlus(z, x *decimal.Big) *decimal.Big {
 	return z
 }
 
+// NextToward sets z to the representable number closest to x in the direction
+// of y and returns z. If x == y the result is x with the sign of y.
+//
+// Unlike NextPlus and NextMinus, an infinite result raises Overflow and a
+// subnormal or zero result raises Underflow and Subnormal. Both also raise
+// Inexact and Rounded.
+func NextToward(z, x, y *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("NextToward", func() { NextToward(z, x, y) }, z, x, y)
+		return z
+	}
+
+	if z.CheckNaNs(x, y) {
+		return z
+	}
+
+	switch x.Cmp(y) {
+	case 0:
+		return z.CopySign(x, y)
+	case -1:
+		NextPlus(z, x)
+	default:
+		NextMinus(z, x)
+	}
+
+	if z.IsInf(0) {
+		z.Context.Conditions |= decimal.Overflow | decimal.Inexact | decimal.Rounded
+	} else if z.Sign() == 0 || z.IsSubnormal() {
+		z.Context.Conditions |= decimal.Underflow | decimal.Subnormal |
+			decimal.Inexact | decimal.Rounded
+		if z.Sign() == 0 {
+			z.Context.Conditions |= decimal.Clamped
+		}
+	}
+	return z
+}
+
 func ord(x *decimal.Big, abs bool) (r int) {
 	// -2 == -qnan
 	// -1 == -snan
@@ -261,14 +434,46 @@ func precision(z *decimal.Big) int {
 	if p > 0 && p <= decimal.UnlimitedPrecision {
 		return p
 	}
-	if p == 0 {
-		z.Context.Precision = decimal.DefaultPrecision
-	} else {
+	if p != 0 {
 		z.Context.Conditions |= decimal.InvalidContext
 	}
+	// Like the decimal package, a zero precision means the default Context's.
+	if p = decimal.DefaultContext().Precision; p != 0 {
+		return p
+	}
 	return decimal.DefaultPrecision
 }
 
+// Scaleb sets z to x × 10**y and returns z. y must be an integer with a scale of
+// zero in the range [-2 × (MaxScale + precision), 2 × (MaxScale + precision)],
+// otherwise z is set to NaN and InvalidOperation is raised. The result is
+// rounded to z's precision, which can overflow, underflow, or clamp the result.
+// Infinities are copied unchanged.
+func Scaleb(z, x, y *decimal.Big) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Scaleb", func() { Scaleb(z, x, y) }, z, x, y)
+		return z
+	}
+
+	if z.CheckNaNs(x, y) {
+		return z
+	}
+
+	n, ok := y.Int64()
+	limit := 2 * (int64(maxscl(z)) + int64(precision(z)))
+	if !ok || y.Scale() != 0 || n < -limit || n > limit {
+		z.Context.Conditions |= decimal.InvalidOperation
+		return z.SetNaN(false)
+	}
+	if x.IsInf(0) {
+		return z.SetInf(x.Signbit())
+	}
+
+	scale := x.Scale()
+	z.Copy(x)
+	return z.Context.Round(z.SetScale(scale - int(n)))
+}
+
 // SameQuantum returns true if x and y have the same exponent (scale).
 func SameQuantum(x, y *decimal.Big) bool { return x.Scale() == y.Scale() }
 
@@ -334,6 +539,52 @@ func Shift(z, x *decimal.Big, shift int) *decimal.Big {
 }
 */
 
+// Rotate sets z to the digit-wise rotation of x's coefficient and returns z.
+// The coefficient is padded on the left with zeros, or loses its most
+// significant digits, so it has exactly z's precision digits. A positive shift
+// rotates to the left; a negative shift rotates to the right. The shift must be
+// in the range [-precision, precision], otherwise z is set to NaN and
+// InvalidOperation is raised. z has the same sign and scale as x. Infinities
+// are copied unchanged. If z's precision is decimal.UnlimitedPrecision, only
+// the digits of x are rotated.
+func Rotate(z, x *decimal.Big, shift int) *decimal.Big {
+	if hooked(z) {
+		decimal.Watch("Rotate", func() { Rotate(z, x, shift) }, z, x, decimal.New(int64(shift), 0))
+		return z
+	}
+
+	if z.CheckNaNs(x, nil) {
+		return z
+	}
+
+	s := digits(x)
+	prec := precision(z)
+	if prec == decimal.UnlimitedPrecision {
+		prec = len(s)
+	}
+	if shift < -prec || shift > prec {
+		z.Context.Conditions |= decimal.InvalidOperation
+		return z.SetNaN(false)
+	}
+	if x.IsInf(0) {
+		return z.SetInf(x.Signbit())
+	}
+
+	if len(s) > prec {
+		s = s[len(s)-prec:]
+	}
+	b := make([]byte, prec)
+	for i := range b {
+		b[prec-1-i] = '0' + digitAt(s, i)
+	}
+	if shift < 0 {
+		shift += prec
+	}
+	r := make([]byte, 0, prec)
+	r = append(append(r, b[shift:]...), b[:shift]...)
+	return setDigits(z, r, x.Scale(), x.Signbit())
+}
+
 // SetSignbit sets z to -z if sign is true, otherwise to +z.
 func SetSignbit(z *decimal.Big, sign bool) *decimal.Big {
 	if sign {
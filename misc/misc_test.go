@@ -8,8 +8,11 @@ import (
 	"github.com/ericlagergren/decimal/misc"
 )
 
-func TestBig_NextMinus(t *testing.T) { test.NextMinus.Test(t) }
-func TestBig_NextPlus(t *testing.T)  { test.NextPlus.Test(t) }
+func TestBig_Logb(t *testing.T)       { test.Logb.Test(t) }
+func TestBig_NextMinus(t *testing.T)  { test.NextMinus.Test(t) }
+func TestBig_NextPlus(t *testing.T)   { test.NextPlus.Test(t) }
+func TestBig_NextToward(t *testing.T) { test.NextToward.Test(t) }
+func TestBig_Scaleb(t *testing.T)     { test.Scaleb.Test(t) }
 
 //func TestBig_Shift(t *testing.T)     { test.Shift.Test(t) }
 
@@ -40,3 +43,114 @@ func TestCmpTotal(t *testing.T) {
 		}
 	}
 }
+
+func TestLogical(t *testing.T) {
+	for i, test := range [...]struct {
+		op   string
+		x, y string
+		r    string
+	}{
+		0:  {"and", "0", "0", "0"},
+		1:  {"and", "1", "1", "1"},
+		2:  {"and", "1100", "1010", "1000"},
+		3:  {"and", "1111", "10", "10"},
+		4:  {"and", "1111111111", "1", "1"},
+		5:  {"or", "0", "1", "1"},
+		6:  {"or", "1100", "1010", "1110"},
+		7:  {"or", "1110", "10", "1110"},
+		8:  {"xor", "1100", "1010", "110"},
+		9:  {"xor", "1111", "10", "1101"},
+		10: {"xor", "111111111", "111111111", "0"},
+		11: {"invert", "0", "", "111111111"},
+		12: {"invert", "101010101", "", "10101010"},
+		13: {"invert", "111111111", "", "0"},
+		14: {"invert", "1000000000", "", "111111111"},
+		15: {"and", "2", "1", "NaN"},
+		16: {"or", "-1", "1", "NaN"},
+		17: {"xor", "1E+1", "1", "NaN"},
+		18: {"and", "1", "1.0", "NaN"},
+		19: {"invert", "NaN", "", "NaN"},
+		20: {"or", "1", "Inf", "NaN"},
+	} {
+		ctx := decimal.Context{Precision: 9, OperatingMode: decimal.GDA}
+		x, _ := decimal.WithContext(ctx).SetString(test.x)
+		y, _ := decimal.WithContext(ctx).SetString(test.y)
+		z := decimal.WithContext(ctx)
+		switch test.op {
+		case "and":
+			misc.And(z, x, y)
+		case "or":
+			misc.Or(z, x, y)
+		case "xor":
+			misc.Xor(z, x, y)
+		case "invert":
+			misc.Invert(z, x)
+		}
+		if z.String() != test.r {
+			t.Fatalf("#%d: %s(%s, %s): got %s, wanted %s",
+				i, test.op, test.x, test.y, z, test.r)
+		}
+		if invalid := z.Context.Conditions&decimal.InvalidOperation != 0; invalid != z.IsNaN(0) {
+			t.Fatalf("#%d: %s(%s, %s): unexpected conditions: %s",
+				i, test.op, test.x, test.y, z.Context.Conditions)
+		}
+	}
+}
+
+func TestRotate(t *testing.T) {
+	for i, test := range [...]struct {
+		x     string
+		shift int
+		r     string
+		cond  decimal.Condition
+	}{
+		0:  {"34", 8, "400000003", 0},
+		1:  {"12", 9, "12", 0},
+		2:  {"123456789", -2, "891234567", 0},
+		3:  {"123456789", 0, "123456789", 0},
+		4:  {"123456789", 2, "345678912", 0},
+		5:  {"-1234567890", 1, "-345678902", 0},
+		6:  {"1.2E-5", 1, "0.000120", 0},
+		7:  {"-Inf", 3, "-Infinity", 0},
+		8:  {"1", 10, "NaN", decimal.InvalidOperation},
+		9:  {"1", -10, "NaN", decimal.InvalidOperation},
+		10: {"sNaN", 1, "NaN", decimal.InvalidOperation},
+		11: {"NaN", 1, "NaN", 0},
+	} {
+		ctx := decimal.Context{Precision: 9, OperatingMode: decimal.GDA}
+		x, _ := decimal.WithContext(ctx).SetString(test.x)
+		z := misc.Rotate(decimal.WithContext(ctx), x, test.shift)
+		if z.String() != test.r || z.Context.Conditions != test.cond {
+			t.Fatalf("#%d: Rotate(%s, %d): got %s (%s), wanted %s (%s)",
+				i, test.x, test.shift, z, z.Context.Conditions, test.r, test.cond)
+		}
+	}
+}
+
+func TestHook(t *testing.T) {
+	var r decimal.Recorder
+	ctx := decimal.Context{
+		Precision:     5,
+		OperatingMode: decimal.GDA,
+		Options:       &decimal.Options{Hook: &r},
+	}
+
+	z := decimal.WithContext(ctx)
+	misc.Logb(z, decimal.New(0, 0))
+	misc.NextToward(z, decimal.New(1, 0), decimal.New(2, 0))
+	misc.Rotate(z, decimal.New(12, 0), 10)
+
+	want := []string{
+		"Logb(0) = -Infinity [5, ToNearestEven]: division by zero",
+		"Rotate(12, 10) = NaN [5, ToNearestEven]: invalid operation",
+	}
+	events := r.Events()
+	if len(events) != len(want) {
+		t.Fatalf("wanted %d events, got %d: %v", len(want), len(events), events)
+	}
+	for i, e := range events {
+		if s := e.String(); s != want[i] {
+			t.Fatalf("#%d: wanted %q, got %q", i, want[i], s)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package decimal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Parser parses decimal strings with a configurable grammar and reports
+// invalid strings with a *SyntaxError. For example, to accept amounts entered
+// by users like " 1_000.50 ", but not "NaN":
+//
+//   p := decimal.Parser{Underscores: true, NoSpecials: true, TrimSpace: true}
+//   x, err := p.Parse(new(decimal.Big), input)
+//
+// The zero Parser accepts the same strings as SetString.
+type Parser struct {
+	// Strict limits the grammar to the General Decimal Arithmetic
+	// specification's numeric strings, so infinities must be spelled
+	// "Infinity" or "Inf" and NaNs "NaN" or "sNaN". Otherwise, infinities
+	// and NaNs are case-insensitive and "qNaN" is accepted. The other
+	// options extend the grammar even if Strict is set.
+	//
+	// http://speleotrove.com/decimal/daconvs.html#refnumsyn
+	Strict bool
+
+	// Underscores allows underscores between digits, like Go's numeric
+	// literals. For example, "1_000.000_1".
+	Underscores bool
+
+	// NoSpecials rejects infinities and NaNs.
+	NoSpecials bool
+
+	// TrimSpace allows leading and trailing white space.
+	TrimSpace bool
+}
+
+// SyntaxError describes a string that couldn't be parsed.
+type SyntaxError struct {
+	// Input is the string being parsed.
+	Input string
+
+	// Offset is the byte offset in Input of the problem, or len(Input) if
+	// Input ended too soon.
+	Offset int
+
+	// Reason describes the problem, e.g. "unexpected 'x'".
+	Reason string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("decimal: parsing %q: %s at offset %d", e.Input, e.Reason, e.Offset)
+}
+
+// Unwrap returns ConversionSyntax, so errors.Is(err, ConversionSyntax)
+// reports whether err is a *SyntaxError.
+func (e *SyntaxError) Unwrap() error { return ConversionSyntax }
+
+var _ error = (*SyntaxError)(nil)
+
+// Parse sets z to the value of s and returns z. If s isn't valid, Parse
+// returns nil and a *SyntaxError, and z is unchanged.
+//
+// Like SetString, the value isn't rounded, and z's Context is replaced by the
+// default Context if it's the zero Context. Conditions like
+// InsufficientStorage that depend on the value rather than the syntax are
+// added to z's Context instead of being returned.
+func (p Parser) Parse(z *Big, s string) (*Big, error) {
+	ps := parseState{Parser: p, s: s}
+	t, err := ps.parse()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := z.SetString(t); !ok {
+		return nil, ps.errorf(len(s), "invalid syntax")
+	}
+	return z, nil
+}
+
+// parseState is the state of a call to Parser.Parse.
+type parseState struct {
+	Parser
+	s     string
+	i     int  // offset of the next byte in s
+	end   int  // offset of the end of s, excluding trailing white space
+	under bool // whether s contains underscores
+}
+
+func (ps *parseState) errorf(i int, format string, args ...interface{}) error {
+	return &SyntaxError{Input: ps.s, Offset: i, Reason: fmt.Sprintf(format, args...)}
+}
+
+// unexpected returns an error for the character at ps.i.
+func (ps *parseState) unexpected() error {
+	if ps.i >= ps.end {
+		return ps.errorf(ps.i, "unexpected end of input")
+	}
+	r, _ := utf8.DecodeRuneInString(ps.s[ps.i:ps.end])
+	return ps.errorf(ps.i, "unexpected %q", r)
+}
+
+// peek returns the byte at ps.i, or 0 at the end of the input.
+func (ps *parseState) peek() byte {
+	if ps.i < ps.end {
+		return ps.s[ps.i]
+	}
+	return 0
+}
+
+// parse checks ps.s and returns it in the form accepted by SetString.
+func (ps *parseState) parse() (string, error) {
+	ps.end = len(ps.s)
+	if ps.TrimSpace {
+		ps.i = len(ps.s) - len(strings.TrimLeftFunc(ps.s, unicode.IsSpace))
+		ps.end = len(strings.TrimRightFunc(ps.s, unicode.IsSpace))
+		if ps.end < ps.i {
+			ps.end = ps.i
+		}
+	}
+	start := ps.i
+	if ps.i == ps.end {
+		return "", ps.errorf(ps.i, "empty string")
+	}
+
+	if c := ps.peek(); c == '+' || c == '-' {
+		ps.i++
+	}
+	if isLetter(ps.peek()) {
+		if err := ps.special(); err != nil {
+			return "", err
+		}
+		return ps.s[start:ps.end], nil
+	}
+
+	// decimal-part ::= digits '.' [digits] | ['.'] digits
+	n, err := ps.digits()
+	if err != nil {
+		return "", err
+	}
+	if ps.peek() == '.' {
+		ps.i++
+		m, err := ps.digits()
+		if err != nil {
+			return "", err
+		}
+		n += m
+	}
+	if n == 0 {
+		if ps.i < ps.end && ps.peek() != 'e' && ps.peek() != 'E' {
+			return "", ps.unexpected()
+		}
+		return "", ps.errorf(ps.i, "missing digits")
+	}
+
+	// exponent-part ::= indicator [sign] digits
+	if c := ps.peek(); c == 'e' || c == 'E' {
+		ps.i++
+		if c := ps.peek(); c == '+' || c == '-' {
+			ps.i++
+		}
+		n, err := ps.digits()
+		if err != nil {
+			return "", err
+		}
+		if n == 0 {
+			if ps.i < ps.end {
+				return "", ps.unexpected()
+			}
+			return "", ps.errorf(ps.i, "missing exponent digits")
+		}
+	}
+
+	if ps.i < ps.end {
+		return "", ps.unexpected()
+	}
+	t := ps.s[start:ps.end]
+	if ps.under {
+		t = strings.Replace(t, "_", "", -1)
+	}
+	return t, nil
+}
+
+// digits consumes a run of digits and returns the number of digits.
+func (ps *parseState) digits() (int, error) {
+	n := 0
+	for ps.i < ps.end {
+		c := ps.s[ps.i]
+		if c == '_' && ps.Underscores {
+			// Like Go's numeric literals, underscores must separate
+			// successive digits.
+			if n == 0 || ps.i+1 >= ps.end || !isDigit(ps.s[ps.i+1]) {
+				return n, ps.errorf(ps.i, "'_' must separate successive digits")
+			}
+			ps.under = true
+			ps.i++
+			continue
+		}
+		if !isDigit(c) {
+			break
+		}
+		n++
+		ps.i++
+	}
+	return n, nil
+}
+
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+
+// special checks the infinity or NaN at ps.i, which must start with a letter.
+func (ps *parseState) special() error {
+	start := ps.i
+	for ps.i < ps.end && isLetter(ps.s[ps.i]) {
+		ps.i++
+	}
+	word := ps.s[start:ps.i]
+
+	var nan bool
+	if ps.Strict {
+		switch word {
+		case "Infinity", "Inf":
+		case "NaN", "sNaN":
+			nan = true
+		default:
+			return ps.badSpecial(start, word)
+		}
+	} else {
+		switch strings.ToLower(word) {
+		case "infinity", "inf":
+		case "nan", "qnan", "snan":
+			nan = true
+		default:
+			return ps.badSpecial(start, word)
+		}
+	}
+	if ps.NoSpecials {
+		return ps.errorf(start, "%s not allowed", word)
+	}
+
+	if !nan {
+		if ps.i < ps.end {
+			return ps.unexpected()
+		}
+		return nil
+	}
+
+	// The payload must fit into a uint64.
+	p := strings.TrimLeft(ps.s[ps.i:ps.end], "0")
+	for j := 0; j < len(p); j++ {
+		if !isDigit(p[j]) {
+			ps.i = ps.end - len(p) + j
+			return ps.unexpected()
+		}
+	}
+	if p != "" {
+		if _, err := strconv.ParseUint(p, 10, 64); err != nil {
+			return ps.errorf(ps.i, "NaN payload out of range")
+		}
+	}
+	ps.i = ps.end
+	return nil
+}
+
+// badSpecial returns an error for the word at start, which isn't an infinity
+// or NaN.
+func (ps *parseState) badSpecial(start int, word string) error {
+	switch word[0] {
+	case 'i', 'I', 'n', 'N', 's', 'S', 'q', 'Q':
+		return ps.errorf(start, "invalid infinity or NaN %q", word)
+	}
+	ps.i = start
+	return ps.unexpected()
+}
@@ -0,0 +1,112 @@
+package decimal_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestParser(t *testing.T) {
+	var (
+		lenient = decimal.Parser{}
+		strict  = decimal.Parser{Strict: true}
+		amounts = decimal.Parser{Underscores: true, NoSpecials: true, TrimSpace: true}
+	)
+	for i, test := range [...]struct {
+		p      decimal.Parser
+		in     string
+		want   string // if valid
+		offset int    // if invalid
+		reason string // if invalid
+	}{
+		{p: lenient, in: "1234.5", want: "1234.5"},
+		{p: lenient, in: "-.5e-3", want: "-0.0005"},
+		{p: lenient, in: "+1.E+2", want: "1E+2"},
+		{p: lenient, in: "-infinity", want: "-Infinity"},
+		{p: lenient, in: "qNaN007", want: "NaN7"},
+		{p: lenient, in: "", offset: 0, reason: "empty string"},
+		{p: lenient, in: "1,000", offset: 1, reason: "unexpected ','"},
+		{p: lenient, in: "1.2.3", offset: 3, reason: "unexpected '.'"},
+		{p: lenient, in: "-", offset: 1, reason: "missing digits"},
+		{p: lenient, in: ".e5", offset: 1, reason: "missing digits"},
+		{p: lenient, in: "1e", offset: 2, reason: "missing exponent digits"},
+		{p: lenient, in: "1e+x", offset: 3, reason: "unexpected 'x'"},
+		{p: lenient, in: "12€", offset: 2, reason: "unexpected '€'"},
+		{p: lenient, in: " 1", offset: 0, reason: "unexpected ' '"},
+		{p: lenient, in: "1_000", offset: 1, reason: "unexpected '_'"},
+		{p: lenient, in: "Infinit", offset: 0, reason: `invalid infinity or NaN "Infinit"`},
+		{p: lenient, in: "x1", offset: 0, reason: "unexpected 'x'"},
+		{p: lenient, in: "Inf1", offset: 3, reason: "unexpected '1'"},
+		{p: lenient, in: "NaN1x", offset: 4, reason: "unexpected 'x'"},
+		{p: lenient, in: "NaN123456789012345678901", offset: 3, reason: "NaN payload out of range"},
+
+		{p: strict, in: "-Inf", want: "-Infinity"},
+		{p: strict, in: "sNaN12", want: "sNaN12"},
+		{p: strict, in: "inf", offset: 0, reason: `invalid infinity or NaN "inf"`},
+		{p: strict, in: "-qNaN", offset: 1, reason: `invalid infinity or NaN "qNaN"`},
+
+		{p: amounts, in: " 1_000.50\t", want: "1000.50"},
+		{p: amounts, in: "1_2e1_0", want: "1.2E+11"},
+		{p: amounts, in: "  ", offset: 2, reason: "empty string"},
+		{p: amounts, in: "1__000", offset: 1, reason: "'_' must separate successive digits"},
+		{p: amounts, in: "_1", offset: 0, reason: "'_' must separate successive digits"},
+		{p: amounts, in: "1_.5", offset: 1, reason: "'_' must separate successive digits"},
+		{p: amounts, in: "1._5", offset: 2, reason: "'_' must separate successive digits"},
+		{p: amounts, in: " NaN", offset: 1, reason: "NaN not allowed"},
+		{p: amounts, in: "-Inf", offset: 1, reason: "Inf not allowed"},
+		{p: amounts, in: " 1 2 ", offset: 2, reason: "unexpected ' '"},
+	} {
+		z := decimal.New(42, 0)
+		x, err := test.p.Parse(z, test.in)
+		if test.reason == "" {
+			if err != nil {
+				t.Fatalf("#%d: Parse(%q): unexpected error: %v", i, test.in, err)
+			}
+			if x != z || x.String() != test.want {
+				t.Fatalf("#%d: Parse(%q): got %s, wanted %s", i, test.in, x, test.want)
+			}
+			continue
+		}
+
+		var se *decimal.SyntaxError
+		if !errors.As(err, &se) {
+			t.Fatalf("#%d: Parse(%q): wanted *SyntaxError, got %v", i, test.in, err)
+		}
+		if se.Offset != test.offset || se.Reason != test.reason || se.Input != test.in {
+			t.Fatalf("#%d: Parse(%q): got %q at %d, wanted %q at %d",
+				i, test.in, se.Reason, se.Offset, test.reason, test.offset)
+		}
+		if !errors.Is(err, decimal.ConversionSyntax) {
+			t.Fatalf("#%d: Parse(%q): errors.Is(err, ConversionSyntax) is false", i, test.in)
+		}
+		if x != nil || z.Cmp(decimal.New(42, 0)) != 0 {
+			t.Fatalf("#%d: Parse(%q): got %v and z = %s, wanted nil and 42", i, test.in, x, z)
+		}
+	}
+}
+
+// TestParser_SetString checks that the zero Parser accepts the same strings as
+// SetString.
+func TestParser_SetString(t *testing.T) {
+	for _, s := range [...]string{
+		"0", "-0", "+0.000", "1.", ".1", "1e5", "1E-5", "1e+05", "-1.5e0",
+		"12345678901234567890123456789.123", "inf", "+INF", "-Infinity",
+		"nan", "-sNaN", "qnan", "NaN00", "NaN18446744073709551615",
+		"", ".", "-", "1e", "e1", "1.2.3", "1..2", "++1", "1e1.5", "1e+",
+		"infinit", "nana", "NaN-1", "Inf1", "0x10", "1 ", " 1", "1_0",
+	} {
+		// SetString reports some invalid strings with ConversionSyntax instead.
+		want, wantOK := new(decimal.Big).SetString(s)
+		if wantOK && want.Context.Conditions&decimal.ConversionSyntax != 0 {
+			wantOK = false
+		}
+		got, err := decimal.Parser{}.Parse(new(decimal.Big), s)
+		if (err == nil) != wantOK {
+			t.Fatalf("%q: SetString returned %t, but Parse returned %v", s, wantOK, err)
+		}
+		if err == nil && got.String() != want.String() {
+			t.Fatalf("%q: SetString set %s, but Parse set %s", s, want, got)
+		}
+	}
+}
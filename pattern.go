@@ -0,0 +1,508 @@
+package decimal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ericlagergren/decimal/internal/arith"
+)
+
+// Pattern is a compiled number pattern, like those used by spreadsheets and
+// ICU's DecimalFormat. For example:
+//
+//   #,##0.00;(#,##0.00)   1,234.50 and (1,234.50)
+//   0.000E+00             1.235E+03
+//   0.00%                 12.35%
+//   #,##0.00 "USD"        1,234.50 USD
+//
+// A pattern has up to three sections separated by ';', which format positive
+// numbers, negative numbers, and zero. If there's no negative section,
+// negative numbers are formatted with the positive section preceded by '-',
+// and if there's no zero section, zero is formatted with the positive section.
+// The negative and zero sections can be literal text without a number, like
+// the "-" in "#,##0.00;(#,##0.00);-", or empty, like the negative section of
+// "0.00;;zero".
+//
+// Each section is a prefix, a number, and a suffix. The number is made of
+// these characters:
+//
+//   0   a digit, or zero if there's no digit there
+//   #   a digit, or nothing if there's no digit there
+//   ,   a grouping separator, which is repeated every n digits, where n is
+//       the number of digits between it and the decimal point; "#,##,##0"
+//       groups like 1,23,45,678
+//   .   the decimal point
+//   E   the exponent, followed by '+' to always write its sign and a '0' for
+//       each digit it has at least
+//
+// In the integer part, '#' must precede '0', and in the fractional part, '0'
+// must precede '#', so "#,##0.00##" has at least 1 integer digit and between
+// 2 and 4 fractional digits. With an exponent, the integer part has as many
+// digits as it has '0's, or, if it has '#'s, the exponent is a multiple of the
+// number of '#'s and '0's, so "##0.0E+0" is engineering notation.
+//
+// The prefix and suffix are literal text, except that '%' multiplies the
+// number by 100 and '‰' (U+2030) by 1000. Text can be quoted with '"', like
+// "USD", or with '\'', where '' is a single quote, and a '\\' quotes the
+// next character.
+//
+// Formatting is exact: the number is rounded to the pattern's digits using its
+// RoundingMode, and infinities and NaNs are written as "Infinity" and "NaN".
+// The zero Pattern isn't valid; use CompilePattern. A Pattern is safe for
+// concurrent use.
+type Pattern struct {
+	pattern  string
+	sections []pattern
+}
+
+// pattern is one section of a Pattern.
+type pattern struct {
+	prefix, suffix string
+	minInt         int  // number of '0's in the integer part
+	maxInt         int  // number of '0's and '#'s in the integer part
+	group1, group2 int  // primary and secondary grouping sizes, or zero
+	minFrac        int  // number of '0's in the fractional part
+	maxFrac        int  // number of '0's and '#'s in the fractional part
+	sci            bool // has an exponent
+	expPlus        bool // always write the exponent's sign
+	minExp         int  // minimum number of exponent digits
+	shift          int  // 2 for percent, 3 for permille
+	text           bool // only literal text, without a number
+}
+
+// CompilePattern parses a number pattern. See Pattern for the syntax. If the
+// pattern is invalid, it returns a *SyntaxError.
+func CompilePattern(s string) (*Pattern, error) {
+	p := &Pattern{pattern: s}
+	for off := 0; ; {
+		sec, n, err := compileSection(s, off)
+		if err != nil {
+			return nil, err
+		}
+		if sec.text && len(p.sections) == 0 {
+			return nil, patternError(s, off+n, "missing digits")
+		}
+		p.sections = append(p.sections, sec)
+		off += n
+		if off == len(s) {
+			break
+		}
+		off++ // ';'
+		if len(p.sections) == 3 {
+			return nil, patternError(s, off-1, "too many sections")
+		}
+	}
+	return p, nil
+}
+
+// MustCompilePattern is like CompilePattern, but panics if the pattern is
+// invalid.
+func MustCompilePattern(s string) *Pattern {
+	p, err := CompilePattern(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func patternError(s string, off int, reason string) error {
+	return &SyntaxError{Input: s, Offset: off, Reason: reason}
+}
+
+// compileSection parses the section of s that starts at off and returns it
+// along with its length, excluding the ';' that ends it.
+func compileSection(s string, off int) (p pattern, n int, err error) {
+	var (
+		prefix, suffix strings.Builder
+		lit            = &prefix // the current literal text
+		state          = 0       // 0: prefix, 1: number, 2: suffix
+		sawPercent     bool
+		start          = off
+	)
+
+	i := off
+	for i < len(s) && s[i] != ';' {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\'':
+			j := i + 1
+			for {
+				k := strings.IndexByte(s[j:], c)
+				if k < 0 {
+					return p, 0, patternError(s, i, "unterminated quote")
+				}
+				if k == 0 && c == '\'' && j == i+1 {
+					// A bare '' is a single quote, not an empty quote.
+					lit.WriteByte('\'')
+					j++
+					break
+				}
+				lit.WriteString(s[j : j+k])
+				j += k + 1
+				if c != '\'' || j >= len(s) || s[j] != '\'' {
+					break
+				}
+				// '' is a single quote, inside quotes or not.
+				lit.WriteByte('\'')
+				j++
+			}
+			i = j
+			if state == 1 {
+				state = 2
+				lit = &suffix
+			}
+			continue
+		case c == '\\':
+			if i+1 == len(s) {
+				return p, 0, patternError(s, i, "trailing '\\'")
+			}
+			_, size := utf8.DecodeRuneInString(s[i+1:])
+			lit.WriteString(s[i+1 : i+1+size])
+			i += 1 + size
+			if state == 1 {
+				state = 2
+				lit = &suffix
+			}
+			continue
+		case strings.IndexByte("#0,.", c) >= 0:
+			if state == 2 {
+				return p, 0, patternError(s, i, fmt.Sprintf("unexpected %q", c))
+			}
+			state = 1
+			m, err := p.compileNumber(s, i)
+			if err != nil {
+				return p, 0, err
+			}
+			i += m
+			state = 2
+			lit = &suffix
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == '%' || r == '‰' {
+			if sawPercent {
+				return p, 0, patternError(s, i, "multiple percent or permille signs")
+			}
+			sawPercent = true
+			p.shift = 2
+			if r == '‰' {
+				p.shift = 3
+			}
+		}
+		lit.WriteString(s[i : i+size])
+		i += size
+	}
+	p.text = state == 0
+	p.prefix, p.suffix = prefix.String(), suffix.String()
+	return p, i - start, nil
+}
+
+// compileNumber parses the number at s[i] and returns its length.
+func (p *pattern) compileNumber(s string, i int) (int, error) {
+	start := i
+	prev := -1  // digits between the last two ','s, if any
+	last := -1  // digits before the last ',', if any
+	digits := 0 // digits after the last ',', or in the integer part
+
+	// Integer part.
+Int:
+	for ; i < len(s); i++ {
+		switch s[i] {
+		case '#':
+			if p.minInt > 0 {
+				return 0, patternError(s, i, "'#' after '0'")
+			}
+		case '0':
+			p.minInt++
+		case ',':
+			if last >= 0 && digits == 0 {
+				return 0, patternError(s, i, "unexpected ','")
+			}
+			prev, last = last, digits
+			digits = 0
+			continue
+		default:
+			break Int
+		}
+		p.maxInt++
+		digits++
+	}
+	if last >= 0 {
+		if digits == 0 {
+			return 0, patternError(s, i-1, "grouping separator without digits")
+		}
+		p.group1 = digits
+		if prev >= 0 {
+			p.group2 = last
+		}
+	}
+
+	// Fractional part.
+	if i < len(s) && s[i] == '.' {
+	Frac:
+		for i++; i < len(s); i++ {
+			switch s[i] {
+			case '0':
+				if p.maxFrac > p.minFrac {
+					return 0, patternError(s, i, "'0' after '#'")
+				}
+				p.minFrac++
+			case '#':
+			case ',', '.':
+				return 0, patternError(s, i, fmt.Sprintf("unexpected %q", s[i]))
+			default:
+				break Frac
+			}
+			p.maxFrac++
+		}
+	}
+
+	if p.maxInt == 0 && p.maxFrac == 0 {
+		return 0, patternError(s, start, "missing digits")
+	}
+	if i < len(s) && (s[i] == 'E' || s[i] == 'e') {
+		p.sci = true
+		e := i
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			p.expPlus = s[i] == '+'
+			i++
+		}
+		for ; i < len(s) && s[i] == '0'; i++ {
+			p.minExp++
+		}
+		if p.minExp == 0 {
+			return 0, patternError(s, e, "missing exponent digits")
+		}
+		p.group1, p.group2 = 0, 0
+	}
+	if p.group2 == 0 {
+		p.group2 = p.group1
+	}
+	return i - start, nil
+}
+
+// String returns the source of the pattern.
+func (p *Pattern) String() string { return p.pattern }
+
+// Format returns x formatted using p.
+func (p *Pattern) Format(x *Big) string {
+	var buf [32]byte
+	return string(p.Append(buf[:0], x))
+}
+
+// Append appends x formatted using p to buf and returns the extended buffer.
+func (p *Pattern) Append(buf []byte, x *Big) []byte {
+	if debug {
+		x.validate()
+	}
+
+	if x.IsNaN(0) {
+		return append(buf, "NaN"...)
+	}
+
+	sec := &p.sections[0]
+	neg := x.Sign() < 0
+	if neg && len(p.sections) > 1 {
+		sec = &p.sections[1]
+	} else if x.Sign() == 0 && len(p.sections) > 2 {
+		sec = &p.sections[2]
+	} else if neg {
+		buf = append(buf, '-')
+	}
+
+	buf = append(buf, sec.prefix...)
+	if sec.text {
+		return buf
+	}
+	if x.IsInf(0) {
+		buf = append(buf, "Infinity"...)
+	} else {
+		buf = sec.appendNumber(buf, x)
+	}
+	return append(buf, sec.suffix...)
+}
+
+// appendNumber appends |x|, which must be finite, to buf.
+func (p *pattern) appendNumber(buf []byte, x *Big) []byte {
+	var tmp [20]byte
+	b := x.coeffDigits(tmp[:0])
+	exp := x.exp + p.shift
+	if x.compact == 0 {
+		b, exp = b[:1], 0
+	}
+
+	if p.sci {
+		return p.appendSci(buf, x, b, exp)
+	}
+
+	// Round so the last digit is at most maxFrac digits after the decimal
+	// point.
+	b, exp = p.round(x, b, exp, len(b)+exp+p.maxFrac)
+
+	// Split the digits into the integer part and the fractional part.
+	var ip, fp []byte
+	switch n := len(b) + exp; {
+	case exp >= 0:
+		ip = appendZeros(b, exp)
+	case n > 0:
+		ip, fp = b[:n], b[n:]
+	default:
+		fp = append(appendZeros(nil, -n), b...)
+	}
+	for len(ip) > 0 && ip[0] == '0' {
+		ip = ip[1:]
+	}
+	for len(fp) > p.minFrac && fp[len(fp)-1] == '0' {
+		fp = fp[:len(fp)-1]
+	}
+
+	// Integer part.
+	pad := p.minInt - len(ip)
+	if pad < 0 {
+		pad = 0
+	} else if pad == 0 && len(ip) == 0 && len(fp) == 0 && p.minFrac == 0 {
+		// Write "0" rather than nothing.
+		pad = 1
+	}
+	total := pad + len(ip)
+	for k := 0; k < total; k++ {
+		if r := total - k; k > 0 && p.group1 > 0 && r >= p.group1 && (r-p.group1)%p.group2 == 0 {
+			buf = append(buf, ',')
+		}
+		if k < pad {
+			buf = append(buf, '0')
+		} else {
+			buf = append(buf, ip[k-pad])
+		}
+	}
+
+	// Fractional part.
+	if len(fp) > 0 || p.minFrac > 0 {
+		buf = append(buf, '.')
+		buf = append(buf, fp...)
+		buf = appendZeros(buf, p.minFrac-len(fp))
+	}
+	return buf
+}
+
+// appendSci appends |x|, whose digits are b with the exponent exp, to buf in
+// scientific notation.
+func (p *pattern) appendSci(buf []byte, x *Big, b []byte, exp int) []byte {
+	// The exponent is adjusted so the integer part has minInt digits, or,
+	// with '#', 1 to maxInt digits and the exponent is a multiple of maxInt.
+	adj := exp + len(b) - 1
+	intDigits := func(adj int) (n, e int) {
+		if p.maxInt > p.minInt && p.maxInt > 1 {
+			m := adj % p.maxInt
+			if m < 0 {
+				m += p.maxInt
+			}
+			return m + 1, adj - m
+		}
+		n = p.minInt
+		if n == 0 {
+			n = 1
+		}
+		return n, adj - (n - 1)
+	}
+
+	n, e := intDigits(adj)
+	if x.compact == 0 {
+		n, e = 1, 0
+		if p.minInt > 1 {
+			n = p.minInt
+		}
+	}
+	keep := n + p.maxFrac
+	b, _ = p.round(x, b, exp, keep)
+	if len(b) > keep {
+		// Rounding carried into a new digit, like 9.99 to 10.0.
+		b = b[:keep]
+		if x.compact != 0 {
+			adj++
+			n, e = intDigits(adj)
+			keep = n + p.maxFrac
+		}
+	}
+	for len(b) < keep {
+		b = append(b, '0')
+	}
+
+	buf = append(buf, b[:n]...)
+	fp := b[n:]
+	for len(fp) > p.minFrac && fp[len(fp)-1] == '0' {
+		fp = fp[:len(fp)-1]
+	}
+	if len(fp) > 0 {
+		buf = append(buf, '.')
+		buf = append(buf, fp...)
+	}
+
+	buf = append(buf, 'E')
+	if e < 0 {
+		buf = append(buf, '-')
+		e = -e
+	} else if p.expPlus {
+		buf = append(buf, '+')
+	}
+	if d := arith.Length(uint64(e)); d < p.minExp {
+		buf = appendZeros(buf, p.minExp-d)
+	}
+	return strconv.AppendInt(buf, int64(e), 10)
+}
+
+// round rounds the digits b with the exponent exp to prec digits using x's
+// RoundingMode and returns the digits and the exponent of the last one. If
+// prec is at least len(b), b is returned unchanged. If rounding carries into a
+// new digit, the result has prec+1 digits.
+func (p *pattern) round(x *Big, b []byte, exp, prec int) ([]byte, int) {
+	if prec >= len(b) {
+		return b, exp
+	}
+	if prec <= 0 {
+		return p.roundAll(x, b, exp, prec)
+	}
+	exp += len(b) - prec
+	b = roundString(b, x.Context.RoundingMode, x.Context.source(), !x.Signbit(), prec)
+	return b, exp
+}
+
+// roundAll rounds b, whose digits are all rounded off, to a single digit: 0 or
+// one unit in the last place. b is the fraction 0.b with -prec zeros after the
+// decimal point, which are never written out since prec can be arbitrarily
+// small.
+func (p *pattern) roundAll(x *Big, b []byte, exp, prec int) ([]byte, int) {
+	exp += len(b) - prec
+	if allZeros(b) {
+		return append(b[:0], '0'), exp
+	}
+	var inc bool
+	switch m := x.Context.RoundingMode; m {
+	case Stochastic:
+		// Like stochasticString, only the first 19 digits matter.
+		var tmp [19]byte
+		if -prec < len(tmp) {
+			f := appendZeros(tmp[:0], -prec)
+			f = append(f, b[:min(len(b), len(tmp)-len(f))]...)
+			inc = stochasticString(x.Context.source(), f)
+		}
+	default:
+		if m == Unnecessary {
+			m = ToNearestEven // like roundString
+		}
+		r := -1 // 0.b < ½ if there are zeros after the decimal point
+		if prec == 0 {
+			r = cmpHalf(b)
+		}
+		inc = m.needsInc(0, r, !x.Signbit())
+	}
+	b = append(b[:0], '0')
+	if inc {
+		b[0] = '1'
+	}
+	return b, exp
+}
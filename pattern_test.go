@@ -0,0 +1,146 @@
+package decimal_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestPattern(t *testing.T) {
+	for i, test := range [...]struct {
+		pattern string
+		in      string
+		mode    decimal.RoundingMode
+		want    string
+	}{
+		{pattern: "#,##0.00;(#,##0.00)", in: "1234.5", want: "1,234.50"},
+		{pattern: "#,##0.00;(#,##0.00)", in: "-1234.5", want: "(1,234.50)"},
+		{pattern: "#,##0.00;(#,##0.00)", in: "0", want: "0.00"},
+		{pattern: "#,##0.00;(#,##0.00)", in: "-0.001", want: "(0.00)"},
+		{pattern: "#,##0.00;(#,##0.00)", in: "999999.995", want: "1,000,000.00"},
+		{pattern: "#,##0.00;(#,##0.00)", in: "1E+20", want: "100,000,000,000,000,000,000.00"},
+		{pattern: "#,##0.00", in: "-1234.5", want: "-1,234.50"},
+		{pattern: "#,##0.00", in: "123", want: "123.00"},
+		{pattern: "#,##0.00;(#,##0.00);-", in: "0.00", want: "-"},
+		{pattern: "#,##0.00;(#,##0.00);-", in: "5", want: "5.00"},
+		{pattern: "#,##,##0", in: "12345678", want: "1,23,45,678"},
+		{pattern: "#,##,##0", in: "999", want: "999"},
+		{pattern: "#,##0.00 \"USD\"", in: "1234.5", want: "1,234.50 USD"},
+		{pattern: "'#'0", in: "7", want: "#7"},
+		{pattern: "0 'o''clock'", in: "7", want: "7 o'clock"},
+		{pattern: "''0", in: "1234.5", want: "'1234"},
+		{pattern: "0''", in: "7", want: "7'"},
+		{pattern: "\\$0.00", in: "7", want: "$7.00"},
+		{pattern: "0.00%", in: "0.12345", want: "12.34%"},
+		{pattern: "0.00%", in: "0.12345", mode: decimal.ToNearestAway, want: "12.35%"},
+		{pattern: "0.0‰", in: "0.01234", want: "12.3‰"},
+		{pattern: "0.##", in: "1.5", want: "1.5"},
+		{pattern: "0.##", in: "1.999", want: "2"},
+		{pattern: "#.##", in: "0", want: "0"},
+		{pattern: "#.##", in: "0.5", want: ".5"},
+		{pattern: "#.##", in: "0.001", want: "0"},
+		{pattern: "000", in: "7", want: "007"},
+		{pattern: "0.00##", in: "3.14159", want: "3.1416"},
+		{pattern: "0", in: "2.5", want: "2"},
+		{pattern: "0", in: "2.5", mode: decimal.ToNearestAway, want: "3"},
+		{pattern: "0", in: "-2.1", mode: decimal.ToPositiveInf, want: "-2"},
+		{pattern: "0", in: "-2.1", mode: decimal.ToNegativeInf, want: "-3"},
+		{pattern: "0", in: "0.4", mode: decimal.AwayFromZero, want: "1"},
+		{pattern: "0.000", in: "12345678901234567890.0005", want: "12345678901234567890.000"},
+		{pattern: "0.000", in: "12345678901234567890.0005", mode: decimal.AwayFromZero, want: "12345678901234567890.001"},
+
+		{pattern: "0.00", in: "1E-100000000", want: "0.00"},
+		{pattern: "0.00", in: "1E-100000000", mode: decimal.ToPositiveInf, want: "0.01"},
+		{pattern: "0.00", in: "-1E-100000000", mode: decimal.ToNegativeInf, want: "-0.01"},
+		{pattern: "0.00", in: "0.004", mode: decimal.AwayFromZero, want: "0.01"},
+		{pattern: "0.00", in: "0.005", want: "0.00"},
+		{pattern: "0.00", in: "0.005", mode: decimal.ToNearestAway, want: "0.01"},
+		{pattern: "0.00", in: "0.0051", want: "0.01"},
+		{pattern: "0.00;;zero", in: "1.5", want: "1.50"},
+		{pattern: "0.00;;zero", in: "-1.5", want: ""},
+		{pattern: "0.00;;zero", in: "0", want: "zero"},
+
+		{pattern: "0.000E+00", in: "1234.5", want: "1.234E+03"},
+		{pattern: "0.000E+00", in: "1234.5", mode: decimal.ToNearestAway, want: "1.235E+03"},
+		{pattern: "0.000E+00", in: "0.00012", want: "1.200E-04"},
+		{pattern: "0.000E+00", in: "9.9996", want: "1.000E+01"},
+		{pattern: "0.000E+00", in: "0", want: "0.000E+00"},
+		{pattern: "0.000E+00", in: "-1234.5", want: "-1.234E+03"},
+		{pattern: "0.###E0", in: "1500", want: "1.5E3"},
+		{pattern: "00.##E0", in: "12345", want: "12.34E3"},
+		{pattern: "##0.##E0", in: "12345", want: "12.34E3"},
+		{pattern: "##0.##E0", in: "123456", want: "123.46E3"},
+		{pattern: "##0.##E0", in: "0.0012", want: "1.2E-3"},
+		{pattern: "##0.##E0", in: "999.999", want: "1E3"},
+
+		{pattern: "#,##0.00;(#,##0.00)", in: "Infinity", want: "Infinity"},
+		{pattern: "#,##0.00;(#,##0.00)", in: "-Infinity", want: "(Infinity)"},
+		{pattern: "0.00%", in: "-Infinity", want: "-Infinity%"},
+		{pattern: "0.00%", in: "NaN", want: "NaN"},
+	} {
+		p, err := decimal.CompilePattern(test.pattern)
+		if err != nil {
+			t.Fatalf("#%d: CompilePattern(%q): %v", i, test.pattern, err)
+		}
+		if p.String() != test.pattern {
+			t.Fatalf("#%d: String: got %q, wanted %q", i, p, test.pattern)
+		}
+		x, ok := new(decimal.Big).SetString(test.in)
+		if !ok {
+			t.Fatalf("#%d: SetString(%q) failed", i, test.in)
+		}
+		x.Context.RoundingMode = test.mode
+		if got := p.Format(x); got != test.want {
+			t.Fatalf("#%d: %q.Format(%s) [%s]: got %q, wanted %q",
+				i, test.pattern, test.in, test.mode, got, test.want)
+		}
+		if got := string(p.Append([]byte("x="), x)); got != "x="+test.want {
+			t.Fatalf("#%d: %q.Append(%s): got %q, wanted %q",
+				i, test.pattern, test.in, got, "x="+test.want)
+		}
+	}
+}
+
+func TestCompilePattern_Error(t *testing.T) {
+	for i, test := range [...]struct {
+		pattern string
+		offset  int
+		reason  string
+	}{
+		{"", 0, "missing digits"},
+		{"USD", 3, "missing digits"},
+		{";0", 0, "missing digits"},
+		{"0;0;0;0", 5, "too many sections"},
+		{"0#", 1, "'#' after '0'"},
+		{"0.#0", 3, "'0' after '#'"},
+		{"0.0.0", 3, "unexpected '.'"},
+		{"#,,##0", 2, "unexpected ','"},
+		{"#,##0,", 5, "grouping separator without digits"},
+		{"0E", 1, "missing exponent digits"},
+		{"0E+", 1, "missing exponent digits"},
+		{"0 \"USD", 2, "unterminated quote"},
+		{"0\\", 1, "trailing '\\'"},
+		{"0%%", 2, "multiple percent or permille signs"},
+		{"0 0", 2, "unexpected '0'"},
+	} {
+		_, err := decimal.CompilePattern(test.pattern)
+		var se *decimal.SyntaxError
+		if !errors.As(err, &se) {
+			t.Fatalf("#%d: CompilePattern(%q): wanted *SyntaxError, got %v", i, test.pattern, err)
+		}
+		if se.Offset != test.offset || se.Reason != test.reason || se.Input != test.pattern {
+			t.Fatalf("#%d: CompilePattern(%q): got %q at %d, wanted %q at %d",
+				i, test.pattern, se.Reason, se.Offset, test.reason, test.offset)
+		}
+	}
+}
+
+func TestPattern_Append_Allocs(t *testing.T) {
+	p := decimal.MustCompilePattern("#,##0.00;(#,##0.00)")
+	x := decimal.New(-123456789, 3)
+	buf := make([]byte, 0, 64)
+	if n := testing.AllocsPerRun(100, func() { buf = p.Append(buf[:0], x) }); n != 0 {
+		t.Fatalf("got %v allocations, wanted 0", n)
+	}
+}
@@ -4,9 +4,9 @@ package decimal
 
 import "strconv"
 
-const _RoundingMode_name = "ToNearestEvenToNearestAwayToZeroAwayFromZeroToNegativeInfToPositiveInfunnecessary"
+const _RoundingMode_name = "ToNearestEvenToNearestAwayToZeroAwayFromZeroToNegativeInfToPositiveInfToNearestTowardZeroZeroFiveUpToNearestOddStochasticUnnecessaryunnecessary"
 
-var _RoundingMode_index = [...]uint8{0, 13, 26, 32, 44, 57, 70, 81}
+var _RoundingMode_index = [...]uint8{0, 13, 26, 32, 44, 57, 70, 89, 99, 111, 121, 132, 143}
 
 func (i RoundingMode) String() string {
 	if i >= RoundingMode(len(_RoundingMode_index)-1) {
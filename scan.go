@@ -64,6 +64,10 @@ func (z *Big) scan(r io.ByteScanner) error {
 		case strconv.ErrSyntax:
 			z.form = qnan
 			z.Context.Conditions |= ConversionSyntax
+		case InsufficientStorage:
+			z.form = qnan
+			z.compact = uint64(toomanydigits)
+			z.Context.Conditions |= InsufficientStorage
 		}
 		return nil
 	}
@@ -171,9 +175,10 @@ func (z *Big) scanForm(r io.ByteScanner) (form, error) {
 		}
 	}
 
-	// Parse payload
+	// Parse the payload, ignoring leading zeros.
 	var buf [20]byte
-	for i = 0; i < 20; i++ {
+	n := 0
+	for {
 		ch, err := r.ReadByte()
 		if err != nil {
 			if err == io.EOF {
@@ -181,16 +186,22 @@ func (z *Big) scanForm(r io.ByteScanner) (form, error) {
 			}
 			return 0, err
 		}
-		if ch >= '0' && ch <= '9' {
-			buf[i] = ch
+		if ch < '0' || ch > '9' || n == len(buf) {
+			return 0, strconv.ErrSyntax
+		}
+		if n > 0 || ch != '0' {
+			buf[n] = ch
+			n++
 		}
 	}
-	if i > 0 {
-		z.compact, err = strconv.ParseUint(string(buf[:i]), 10, 64)
+	var p uint64
+	if n > 0 {
+		p, err = strconv.ParseUint(string(buf[:n]), 10, 64)
 		if err != nil {
-			return 0, err
+			return 0, strconv.ErrSyntax
 		}
 	}
+	z.compact = p
 
 	if signal {
 		return snan, nil
@@ -202,6 +213,7 @@ func (z *Big) scanForm(r io.ByteScanner) (form, error) {
 type fakeState struct {
 	length int
 	scale  int
+	max    int    // maximum number of digits, if > 0
 	i      int    // index into small
 	small  []byte // buffer of first 20 or so characters.
 	r      io.ByteScanner
@@ -223,6 +235,11 @@ func (f *fakeState) ReadRune() (rune, int, error) {
 
 	if ch >= '0' && ch <= '9' {
 		f.length++
+		// big.Int.Scan unreads the first rune after checking it for a sign,
+		// so length, which starts at -1, is the number of digits read.
+		if f.max > 0 && f.length > f.max {
+			return 0, 0, InsufficientStorage
+		}
 		return rune(ch), 1, nil
 	}
 	if ch == '.' {
@@ -346,7 +363,11 @@ Loop:
 
 	// We can't use length to determine the precision since it'd require we
 	// count (and subtract) any leading zeros, but that's too much overhead for
-	// the general case (i.e., no leading zeros).
+	// the general case (i.e., no leading zeros). For the same reason, leading
+	// zeros count toward MaxDigits.
+	if z.Context.tooManyDigits(length) {
+		return InsufficientStorage
+	}
 
 	// We can tentatively fit into a uint64 if we didn't fill the buffer.
 	if i < len(small) {
@@ -357,7 +378,7 @@ Loop:
 			} else {
 				z.exp = 0
 			}
-			z.precision = arith.Length(z.compact)
+			z.precision = int32(arith.Length(z.compact))
 			if z.compact == c.Inflated {
 				z.unscaled.SetUint64(c.Inflated)
 			}
@@ -379,6 +400,7 @@ Loop:
 			r:      r,
 			scale:  scale,
 			length: -1,
+			max:    z.Context.maxDigits(),
 		}
 		if err := z.unscaled.Scan(&fs, 'd'); err != nil {
 			return err
@@ -388,10 +410,10 @@ Loop:
 		if z.unscaled.Sign() != 0 {
 			if m := z.unscaled.Uint64(); z.unscaled.IsUint64() && m != c.Inflated {
 				z.compact = m
-				z.precision = arith.Length(m)
+				z.precision = int32(arith.Length(m))
 			} else {
 				z.compact = c.Inflated
-				z.precision = arith.BigLength(&z.unscaled)
+				z.precision = int32(arith.BigLength(&z.unscaled))
 			}
 		} else {
 			z.compact = 0
@@ -0,0 +1,221 @@
+package suite
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Mike Cowlishaw's General Decimal Arithmetic test cases are available from:
+// http://speleotrove.com/decimal/dectest.html
+
+// Directives are the settings of a .decTest file. Each directive applies to
+// every test case that follows it until it's changed.
+type Directives struct {
+	Version     string
+	Extended    bool
+	Clamp       bool
+	Precision   int
+	MaxExponent int
+	MinExponent int
+	// Rounding is one of "ceiling", "down", "floor", "half_down",
+	// "half_even", "half_up", "up", or "05up".
+	Rounding string
+}
+
+// DecCase is a test case from a .decTest file.
+//
+// Here's a nice ascii diagram:
+//
+//    id       op   inputs    output  conditions
+//    |        |    |    |    |       |
+//    v        v    v    v    v       v
+//    addx011  add  '1'  1.5  -> 2.5  Inexact Rounded
+//
+type DecCase struct {
+	ID     string
+	Op     string // always lower case
+	Inputs []Data
+	Output Data
+	Excep  Condition
+	Directives
+}
+
+func (c DecCase) String() string {
+	return fmt.Sprintf("%s [%d, %s]: %s(%s) = %s %s",
+		c.ID, c.Precision, c.Rounding, c.Op,
+		join(c.Inputs, ", ", -1), c.Output, c.Excep)
+}
+
+// ShortString returns the same as String, except long data values are capped
+// at length digits.
+func (c DecCase) ShortString(length int) string {
+	return fmt.Sprintf("%s [%d, %s]: %s(%s) = %s %s",
+		c.ID, c.Precision, c.Rounding, c.Op,
+		join(c.Inputs, ", ", length), trunc(c.Output, length), c.Excep)
+}
+
+// ParseDecTest returns the test cases in .decTest form read from r. Quotes
+// around operands and results are removed.
+//
+// The dectest directive, which includes another file, is not followed. Parse
+// each file separately instead.
+func ParseDecTest(r io.Reader) (cases []DecCase, err error) {
+	s := bufio.NewScanner(r)
+	s.Split(bufio.ScanLines)
+
+	var dir Directives
+	for line := 1; s.Scan(); line++ {
+		toks, err := decTokens(s.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		if len(toks) == 0 {
+			continue
+		}
+
+		if i := strings.IndexByte(toks[0], ':'); i >= 0 {
+			key := strings.ToLower(toks[0][:i])
+			val := toks[0][i+1:]
+			if val == "" && len(toks) > 1 {
+				val = toks[1]
+			}
+			if err := dir.set(key, val); err != nil {
+				return nil, fmt.Errorf("line %d: %v", line, err)
+			}
+			continue
+		}
+
+		c, err := parseDecCase(toks)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		c.Directives = dir
+		cases = append(cases, c)
+	}
+	return cases, s.Err()
+}
+
+func (d *Directives) set(key, val string) (err error) {
+	switch key {
+	case "version":
+		d.Version = val
+	case "extended":
+		d.Extended, err = parseDecBool(val)
+	case "clamp":
+		d.Clamp, err = parseDecBool(val)
+	case "precision":
+		d.Precision, err = strconv.Atoi(val)
+	case "maxexponent":
+		d.MaxExponent, err = strconv.Atoi(strings.TrimPrefix(val, "+"))
+	case "minexponent":
+		d.MinExponent, err = strconv.Atoi(val)
+	case "rounding":
+		d.Rounding = strings.ToLower(val)
+	case "dectest":
+		// Includes aren't followed.
+	default:
+		return fmt.Errorf("unknown directive %q", key)
+	}
+	return err
+}
+
+func parseDecBool(s string) (bool, error) {
+	switch s {
+	case "0":
+		return false, nil
+	case "1":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid boolean %q", s)
+	}
+}
+
+func parseDecCase(toks []string) (c DecCase, err error) {
+	arrow := -1
+	for i, t := range toks {
+		if t == "->" {
+			arrow = i
+			break
+		}
+	}
+	if arrow < 2 || arrow+1 >= len(toks) {
+		return c, errors.New("malformed test case")
+	}
+
+	c.ID = toks[0]
+	c.Op = strings.ToLower(toks[1])
+	for _, t := range toks[2:arrow] {
+		c.Inputs = append(c.Inputs, Data(t))
+	}
+	c.Output = Data(toks[arrow+1])
+	for _, t := range toks[arrow+2:] {
+		v, ok := decConditions[strings.ToLower(t)]
+		if !ok {
+			return c, fmt.Errorf("unknown condition %q", t)
+		}
+		c.Excep |= v
+	}
+	return c, nil
+}
+
+var decConditions = map[string]Condition{
+	"clamped":              Clamped,
+	"conversion_syntax":    ConversionSyntax,
+	"division_by_zero":     DivisionByZero,
+	"division_impossible":  DivisionImpossible,
+	"division_undefined":   DivisionUndefined,
+	"inexact":              Inexact,
+	"insufficient_storage": InsufficientStorage,
+	"invalid_context":      InvalidContext,
+	"invalid_operation":    InvalidOperation,
+	"overflow":             Overflow,
+	"rounded":              Rounded,
+	"subnormal":            Subnormal,
+	"underflow":            Underflow,
+
+	// Lost_digits is only raised in the subset arithmetic.
+	"lost_digits": 0,
+}
+
+// decTokens splits a line of a .decTest file into tokens, removing any
+// trailing comment. Tokens may be quoted with ' or ", in which case a doubled
+// quote stands for itself.
+func decTokens(line string) (toks []string, err error) {
+	for i := 0; i < len(line); {
+		switch c := line[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '-' && strings.HasPrefix(line[i:], "--"):
+			return toks, nil
+		case c == '\'' || c == '"':
+			var b strings.Builder
+			for i++; ; i++ {
+				if i >= len(line) {
+					return nil, errors.New("unterminated quote")
+				}
+				if line[i] == c {
+					if i+1 < len(line) && line[i+1] == c {
+						i++
+					} else {
+						i++
+						break
+					}
+				}
+				b.WriteByte(line[i])
+			}
+			toks = append(toks, b.String())
+		default:
+			j := i
+			for j < len(line) && line[j] != ' ' && line[j] != '\t' {
+				j++
+			}
+			toks = append(toks, line[i:j])
+			i = j
+		}
+	}
+	return toks, nil
+}
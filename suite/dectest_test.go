@@ -0,0 +1,65 @@
+package suite
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDecTest(t *testing.T) {
+	const input = `
+------------------------------------------------------------------------
+-- A comment.                                                         --
+------------------------------------------------------------------------
+version: 2.59
+
+extended:    1
+precision:   9
+rounding:    half_up
+maxExponent: 384
+minexponent: -383
+clamp:0
+
+addx001 add 1 1 -> 2
+ADDX002 ADD '-0' "1E+2" -> '1E+2' -- A trailing comment.
+
+rounding: 05up
+basx003 toSci 'it''s' -> NaN Conversion_syntax
+quax004 quantize 1.5 1e0 -> 2 Inexact Rounded
+`
+	cases, err := ParseDecTest(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := Directives{
+		Version:     "2.59",
+		Extended:    true,
+		Precision:   9,
+		MaxExponent: 384,
+		MinExponent: -383,
+		Rounding:    "half_up",
+	}
+	dir2 := dir
+	dir2.Rounding = "05up"
+	want := []DecCase{
+		{ID: "addx001", Op: "add", Inputs: []Data{"1", "1"}, Output: "2", Directives: dir},
+		{ID: "ADDX002", Op: "add", Inputs: []Data{"-0", "1E+2"}, Output: "1E+2", Directives: dir},
+		{ID: "basx003", Op: "tosci", Inputs: []Data{"it's"}, Output: "NaN", Excep: ConversionSyntax, Directives: dir2},
+		{ID: "quax004", Op: "quantize", Inputs: []Data{"1.5", "1e0"}, Output: "2", Excep: Inexact | Rounded, Directives: dir2},
+	}
+	if !reflect.DeepEqual(cases, want) {
+		t.Fatalf("wanted %v, got %v", want, cases)
+	}
+
+	for _, s := range [...]string{
+		"precison: 9",
+		"addx001 add 1 1 2",
+		"addx001 add '1 -> 2",
+		"addx001 add 1 -> 2 Inexcat",
+	} {
+		if _, err := ParseDecTest(strings.NewReader(s)); err == nil {
+			t.Fatalf("%q: expected an error", s)
+		}
+	}
+}
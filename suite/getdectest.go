@@ -0,0 +1,63 @@
+// +build ignore
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+const url = "http://speleotrove.com/decimal/dectest.zip"
+
+func main() {
+	const dir = "_testdata"
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	resp, err := http.Get(url)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer resp.Body.Close()
+	buf, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+	if err != nil {
+		log.Fatalln(err)
+	}
+	for _, f := range zr.File {
+		if path.Ext(f.Name) != ".decTest" {
+			continue
+		}
+		if err := extract(filepath.Join(dir, path.Base(f.Name)), f); err != nil {
+			log.Fatalln(err)
+		}
+	}
+}
+
+func extract(name string, f *zip.File) error {
+	r, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	out, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	_, err = io.Copy(out, r)
+	return err
+}
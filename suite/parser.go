@@ -160,6 +160,8 @@ func ParseCase(data []byte) (c Case, err error) {
 			goto st_case_54
 		case 136:
 			goto st_case_136
+		case 137:
+			goto st_case_137
 		case 55:
 			goto st_case_55
 		case 56:
@@ -437,7 +439,7 @@ func ParseCase(data []byte) (c Case, err error) {
 		case 32:
 			goto st5
 		case 48:
-			goto tr21
+			goto tr137
 		case 61:
 			goto tr22
 		case 94:
@@ -2101,9 +2103,25 @@ func ParseCase(data []byte) (c Case, err error) {
 	st_case_90:
 //line parser.go:2112
 		switch data[p] {
-		case 48:
+		case 48, 49:
 			goto st6
-		case 94:
+		case 94, 118:
+			goto st6
+		}
+		goto st0
+	tr137:
+//line parser.rl:19
+		mark = p
+		goto st137
+	st137:
+		if p++; p == pe {
+			goto _test_eof137
+		}
+	st_case_137:
+		switch data[p] {
+		case 32:
+			goto tr23
+		case 53:
 			goto st6
 		}
 		goto st0
@@ -2833,6 +2851,9 @@ func ParseCase(data []byte) (c Case, err error) {
 	_test_eof136:
 		cs = 136
 		goto _test_eof
+	_test_eof137:
+		cs = 137
+		goto _test_eof
 	_test_eof55:
 		cs = 55
 		goto _test_eof
@@ -106,6 +106,9 @@ func ParseCase(data []byte) (c Case, err error) {
             | '=0' # ToNearestEven
             | '=^' # ToNearestAway
 			| '^'  # AwayFromZero
+            | '=v' # ToNearestTowardZero
+            | '05' # ZeroFiveUp
+            | '=1' # ToNearestOdd
         ) >mark %set_mode;
         condition = (
               'x' # Inexact
@@ -0,0 +1,39 @@
+package suite
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestParseCase_Modes(t *testing.T) {
+	for i, test := range [...]struct {
+		mode string
+		want big.RoundingMode
+	}{
+		0: {">", big.ToPositiveInf},
+		1: {"<", big.ToNegativeInf},
+		2: {"0", big.ToZero},
+		3: {"=0", big.ToNearestEven},
+		4: {"=^", big.ToNearestAway},
+		5: {"^", big.AwayFromZero},
+		6: {"=v", toNearestTowardZero},
+		7: {"05", zeroFiveUp},
+		8: {"=1", toNearestOdd},
+	} {
+		c, err := ParseCase([]byte("d64+ " + test.mode + " 1 2 -> 3 x"))
+		if err != nil {
+			t.Fatalf("#%d: %q: %v", i, test.mode, err)
+		}
+		if c.Mode != test.want {
+			t.Fatalf("#%d: %q: wanted %v, got %v", i, test.mode, test.want, c.Mode)
+		}
+		if want := []Data{"1", "2"}; !reflect.DeepEqual(c.Inputs, want) {
+			t.Fatalf("#%d: %q: wanted inputs %q, got %q", i, test.mode, want, c.Inputs)
+		}
+		if c.Output != "3" || c.Excep != Inexact {
+			t.Fatalf("#%d: %q: wanted 3 (%s), got %s (%s)",
+				i, test.mode, Inexact, c.Output, c.Excep)
+		}
+	}
+}
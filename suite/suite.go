@@ -116,12 +116,12 @@ const NoData Data = "#"
 // IsNaN returns two booleans indicating whether the data is a NaN value and
 // whether it's signaling or not.
 func (i Data) IsNaN() (nan, signal bool) {
+	if len(i) > 1 && (i[0] == '-' || i[0] == '+') {
+		i = i[1:]
+	}
 	if len(i) == 1 {
 		return (i == "S" || i == "Q"), i == "S"
 	}
-	if i[0] == '-' {
-		i = i[1:]
-	}
 	return strings.EqualFold(string(i), "nan") ||
 		strings.EqualFold(string(i), "qnan") ||
 		strings.EqualFold(string(i), "snan"), i[0] == 's' || i[0] == 'S'
@@ -238,8 +238,19 @@ var valToMode = map[string]big.RoundingMode{
 	"=0": big.ToNearestEven,
 	"=^": big.ToNearestAway,
 	"^":  big.AwayFromZero,
+	"=v": toNearestTowardZero,
+	"05": zeroFiveUp,
+	"=1": toNearestOdd,
 }
 
+// Rounding modes that math/big doesn't have. They continue big.RoundingMode's
+// values in the same order as decimal.RoundingMode.
+const (
+	toNearestTowardZero = big.ToPositiveInf + 1 + iota
+	zeroFiveUp
+	toNearestOdd
+)
+
 // Op is a specific operation the test case must perform.
 type Op uint8
 
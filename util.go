@@ -12,46 +12,37 @@ func (z *Big) norm() *Big {
 	if z.unscaled.IsUint64() {
 		if v := z.unscaled.Uint64(); v != cst.Inflated {
 			z.compact = v
-			z.precision = arith.Length(v)
+			z.precision = int32(arith.Length(v))
 			return z
 		}
 	}
-	z.precision = arith.BigLength(&z.unscaled)
+	z.precision = int32(arith.BigLength(&z.unscaled))
 	z.compact = cst.Inflated
 	return z
 }
 
-func (c Context) fix(z *Big) *Big {
+func (c *Context) fix(z *Big) *Big {
 	adj := z.adjusted()
 
-	if adj > c.maxScale() {
-		prec := precision(c)
-
+	if adj > c.MaxScale() {
 		if z.compact == 0 {
-			z.exp = c.maxScale()
+			z.exp = c.MaxScale()
+			if c.clamps() {
+				z.exp = c.etop()
+			}
 			z.Context.Conditions |= Clamped
 			return z
 		}
+		return c.overflow(z, z.Signbit())
+	}
 
-		switch m := c.RoundingMode; m {
-		case ToNearestAway, ToNearestEven:
-			z.SetInf(z.Signbit())
-		case AwayFromZero:
-			// OK
-		case ToZero:
-			z.exp = c.maxScale() - prec + 1
-		case ToPositiveInf, ToNegativeInf:
-			if m == ToPositiveInf == z.Signbit() {
-				z.exp = c.maxScale() - prec + 1
-			} else {
-				z.SetInf(false)
-			}
+	if c.clamps() {
+		if top := c.etop(); z.exp > top {
+			return c.clamp(z, top)
 		}
-		z.Context.Conditions |= Overflow | Inexact | Rounded
-		return z
 	}
 
-	if adj < c.minScale() {
+	if adj < c.MinScale() {
 		tiny := c.etiny()
 
 		if z.compact == 0 {
@@ -64,11 +55,12 @@ func (c Context) fix(z *Big) *Big {
 
 		z.Context.Conditions |= Subnormal
 		if z.exp < tiny {
-			if c.shiftr(z, uint64(tiny-z.exp)) {
-				z.compact = 1
-			}
-			z.Context.Conditions |= Underflow
+			shift := uint64(tiny - z.exp)
 			z.exp = tiny
+			if !c.shiftr(z, shift) {
+				z.Context.Conditions |= Underflow
+			}
+			z.Context.Conditions |= Rounded
 			if z.compact == 0 {
 				z.Context.Conditions |= Clamped
 			}
@@ -77,6 +69,88 @@ func (c Context) fix(z *Big) *Big {
 	return z
 }
 
+// clamps returns true if c folds exponents down to etop.
+func (c Context) clamps() bool {
+	return c.Clamp && precision(c) != UnlimitedPrecision
+}
+
+// clamp pads z's coefficient with zeros so its exponent is top, which must be
+// less than z's exponent, and returns z.
+func (c Context) clamp(z *Big, top int) *Big {
+	z.Context.Conditions |= Clamped
+	return z.shiftl(uint64(z.exp - top))
+}
+
+// shiftl pads z's coefficient with n zeros and decrements its exponent by n,
+// leaving its value unchanged, and returns z.
+func (z *Big) shiftl(n uint64) *Big {
+	z.exp -= int(n)
+	if z.compact == 0 {
+		return z
+	}
+	if z.isCompact() {
+		if zc, ok := checked.MulPow10(z.compact, n); ok {
+			z.compact = zc
+			z.precision = int32(arith.Length(zc))
+			return z
+		}
+		z.unscaled.SetUint64(z.compact)
+		z.compact = cst.Inflated
+	}
+	checked.MulBigPow10(&z.unscaled, &z.unscaled, n)
+	z.precision = int32(arith.BigLength(&z.unscaled))
+	return z
+}
+
+// overflow sets z to either signed infinity or the finite number with the
+// largest magnitude, depending on c's RoundingMode, and returns z.
+func (c Context) overflow(z *Big, neg bool) *Big {
+	var sign form
+	if neg {
+		sign = signbit
+	}
+	switch m := c.RoundingMode; m {
+	case ToZero, ZeroFiveUp:
+		z.form = finite | sign
+		c.setMaxFinite(z)
+	case ToPositiveInf, ToNegativeInf:
+		if m == ToPositiveInf == neg {
+			z.form = finite | sign
+			c.setMaxFinite(z)
+		} else {
+			z.SetInf(neg)
+		}
+	case Unnecessary:
+		z.Context.Conditions |= Overflow | Inexact | Rounded
+		return z.setNaN(InvalidOperation, qnan, roundunnec)
+	default:
+		z.SetInf(neg)
+	}
+	z.Context.Conditions |= Overflow | Inexact | Rounded
+	return z
+}
+
+// setMaxFinite sets z to the finite number with the largest magnitude that can
+// be represented in c, retaining z's sign.
+func (c Context) setMaxFinite(z *Big) *Big {
+	prec := precision(c)
+	if prec == UnlimitedPrecision {
+		return z.SetInf(z.Signbit())
+	}
+	if p, ok := arith.Pow10(uint64(prec)); ok {
+		z.compact = p - 1
+	} else {
+		arith.Sub(&z.unscaled, arith.BigPow10(uint64(prec)), 1)
+		z.compact = cst.Inflated
+	}
+	z.precision = int32(prec)
+	z.exp = c.MaxScale() - prec + 1
+	if scale, ok := c.fixedScale(); ok {
+		z.exp = -scale
+	}
+	return z
+}
+
 // alias returns z if z != x, otherwise a newly-allocated big.Int.
 func alias(z, x *big.Int) *big.Int {
 	if z != x {
@@ -96,13 +170,14 @@ func (z *Big) invalidContext(c Context) bool {
 		z.setNaN(InvalidContext, qnan, invctxpltz)
 	case c.Precision > UnlimitedPrecision:
 		z.setNaN(InvalidContext, qnan, invctxpgtu)
-	case c.RoundingMode >= unnecessary:
+	case c.RoundingMode > Unnecessary:
 		z.setNaN(InvalidContext, qnan, invctxrmode)
 	case c.OperatingMode > Go:
 		z.setNaN(InvalidContext, qnan, invctxomode)
-	case c.MaxScale > MaxScale:
+	// Only Options can hold an invalid MaxScale or MinScale.
+	case c.Options != nil && c.Options.MaxScale > MaxScale:
 		z.setNaN(InvalidContext, qnan, invctxsgtu)
-	case c.MinScale < MinScale:
+	case c.Options != nil && c.Options.MinScale < MinScale:
 		z.setNaN(InvalidContext, qnan, invctxsltu)
 	default:
 		return false
@@ -114,7 +189,7 @@ func precision(c Context) (p int) {
 	if p := c.Precision; p != 0 {
 		return p
 	}
-	return DefaultPrecision
+	return defaultPrecision()
 }
 
 // copybits can be useful when we want to allocate a big.Int without calling
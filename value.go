@@ -0,0 +1,152 @@
+package decimal
+
+import (
+	"encoding"
+	"fmt"
+)
+
+// Value is an immutable decimal. Its methods return new Values instead of
+// modifying their receivers, so a Value can be copied, stored in a struct
+// field, and shared between goroutines without making defensive copies.
+//
+// Each Value carries a Context, which its methods use the same way Big's
+// methods use z.Context. The result of an operation has the receiver's
+// Context, with Conditions set to those raised by the operation:
+//
+//   total := decimal.NewValue(decimal.Context128, 1999, 2)
+//   total = total.Mul(decimal.NewValue(decimal.Context128, 3, 0))
+//   if total.Context().Conditions&decimal.Inexact != 0 {
+//       ...
+//   }
+//
+// The zero Value is 0 with the zero Context. Each operation allocates a new
+// Big for its result, and those involving coefficients that don't fit into a
+// uint64 allocate more; see BenchmarkValue in the benchmarks package.
+type Value struct {
+	x *Big // never modified after the Value is created; nil means zero
+}
+
+// zeroValue is the Big used by the zero Value. It must not be modified.
+var zeroValue Big
+
+// NewValue returns a Value with the Context c and the value value * 10 **
+// -scale. See New.
+func NewValue(c Context, value int64, scale int) Value {
+	c.Conditions = 0
+	return Value{WithContext(c).SetMantScale(value, scale)}
+}
+
+// ValueOf returns a Value equal to x with x's Context. x is copied, so later
+// changes to x don't affect the Value.
+func ValueOf(x *Big) Value {
+	z := WithContext(x.Context)
+	z.Context.Conditions = 0
+	return Value{z.Copy(x)}
+}
+
+// ParseValue returns a Value with the Context c and the value of s, rounded
+// according to c. See Context.SetString for valid formats. It returns an
+// *OpError if s is invalid or if parsing s raised a Condition in c.Traps.
+func ParseValue(c Context, s string) (Value, error) {
+	c.Conditions = 0
+	z, err := Checked(c).SetString(WithContext(c), s)
+	if z == nil {
+		return Value{}, err
+	}
+	return Value{z}, err
+}
+
+// big returns the Big underlying x, which must not be modified.
+func (x Value) big() *Big {
+	if x.x == nil {
+		return &zeroValue
+	}
+	return x.x
+}
+
+// alloc returns a new Big with x's Context, without its Conditions, to hold
+// the result of an operation.
+func (x Value) alloc() *Big {
+	z := WithContext(x.big().Context)
+	z.Context.Conditions = 0
+	return z
+}
+
+// Context returns x's Context, including the Conditions raised by the
+// operation that created x.
+func (x Value) Context() Context { return x.big().Context }
+
+// Big returns a copy of x as a *Big, with x's Context.
+func (x Value) Big() *Big {
+	z := WithContext(x.big().Context)
+	return z.Copy(x.big())
+}
+
+// Add returns x + y.
+func (x Value) Add(y Value) Value {
+	z := x.alloc()
+	return Value{z.Context.Add(z, x.big(), y.big())}
+}
+
+// Sub returns x - y.
+func (x Value) Sub(y Value) Value {
+	z := x.alloc()
+	return Value{z.Context.Sub(z, x.big(), y.big())}
+}
+
+// Mul returns x * y.
+func (x Value) Mul(y Value) Value {
+	z := x.alloc()
+	return Value{z.Context.Mul(z, x.big(), y.big())}
+}
+
+// Quo returns x / y.
+func (x Value) Quo(y Value) Value {
+	z := x.alloc()
+	return Value{z.Context.Quo(z, x.big(), y.big())}
+}
+
+// Quantize returns x with the scale n. See Big.Quantize.
+func (x Value) Quantize(n int) Value {
+	z := x.alloc()
+	return Value{z.Context.Quantize(z.Copy(x.big()), n)}
+}
+
+// Neg returns -x.
+func (x Value) Neg() Value {
+	z := x.alloc()
+	return Value{z.Neg(x.big())}
+}
+
+// Cmp compares x and y like Big.Cmp.
+func (x Value) Cmp(y Value) int { return x.big().Cmp(y.big()) }
+
+// Sign returns -1 if x < 0, 0 if x == 0, and +1 if x > 0. See Big.Sign.
+func (x Value) Sign() int { return x.big().Sign() }
+
+// Format implements fmt.Formatter like Big.Format.
+func (x Value) Format(s fmt.State, c rune) { x.big().Format(s, c) }
+
+// String returns the string representation of x like Big.String.
+func (x Value) String() string { return x.big().String() }
+
+// MarshalText implements encoding.TextMarshaler.
+func (x Value) MarshalText() ([]byte, error) { return x.big().MarshalText() }
+
+// UnmarshalText implements encoding.TextUnmarshaler. The new Value keeps x's
+// Context, and the text isn't rounded.
+func (x *Value) UnmarshalText(data []byte) error {
+	z := x.alloc()
+	if err := z.UnmarshalText(data); err != nil {
+		return err
+	}
+	*x = Value{z}
+	return nil
+}
+
+var (
+	_ fmt.Formatter            = Value{}
+	_ fmt.Stringer             = Value{}
+	_ encoding.TextMarshaler   = Value{}
+	_ encoding.TextUnmarshaler = (*Value)(nil)
+)
@@ -0,0 +1,102 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestValue(t *testing.T) {
+	ctx := decimal.Context{Precision: 5}
+	x := decimal.NewValue(ctx, 1999, 2)
+	y := decimal.NewValue(ctx, 3, 0)
+
+	for i, test := range [...]struct {
+		z decimal.Value
+		r string
+		c decimal.Condition
+	}{
+		0: {x.Add(y), "22.99", 0},
+		1: {x.Sub(y), "16.99", 0},
+		2: {x.Mul(y), "59.97", 0},
+		3: {x.Quo(y), "6.6633", decimal.Inexact | decimal.Rounded},
+		4: {x.Quo(y).Quantize(1), "6.7", decimal.Inexact | decimal.Rounded},
+		5: {x.Neg(), "-19.99", 0},
+		6: {decimal.Value{}.Add(x), "19.99", 0},
+	} {
+		if s := test.z.String(); s != test.r {
+			t.Fatalf("#%d: wanted %q, got %q", i, test.r, s)
+		}
+		c := test.z.Context()
+		if c.Conditions != test.c {
+			t.Fatalf("#%d: wanted %q, got %q", i, test.c, c.Conditions)
+		}
+		if c.Precision != ctx.Precision && i != 6 {
+			t.Fatalf("#%d: wrong Context: %+v", i, c)
+		}
+	}
+	if s := x.String(); s != "19.99" {
+		t.Fatalf("receiver was modified: %s", s)
+	}
+	if x.Cmp(y) <= 0 || y.Cmp(x) >= 0 || x.Sign() != 1 || (decimal.Value{}).Sign() != 0 {
+		t.Fatal("bad comparison")
+	}
+
+	// Values don't share memory with Bigs.
+	b := x.Big()
+	b.Add(b, b)
+	v := decimal.ValueOf(b)
+	b.SetMantScale(0, 0)
+	if s := x.String(); s != "19.99" {
+		t.Fatalf("Big modified the Value: %s", s)
+	}
+	if s := v.String(); s != "39.98" {
+		t.Fatalf("ValueOf: wanted %q, got %q", "39.98", s)
+	}
+
+	if _, err := decimal.ParseValue(ctx, "1.2.3"); !errors.Is(err, decimal.ConversionSyntax) {
+		t.Fatalf("ParseValue: wanted ConversionSyntax, got %v", err)
+	}
+	p, err := decimal.ParseValue(ctx, "1.234567")
+	if err != nil || p.String() != "1.2346" {
+		t.Fatalf("ParseValue: got %s, %v", p, err)
+	}
+
+	var order struct{ Price decimal.Value }
+	order.Price = decimal.NewValue(ctx, -150, 3)
+	buf, err := json.Marshal(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	order.Price = decimal.Value{}
+	if err := json.Unmarshal(buf, &order); err != nil {
+		t.Fatal(err)
+	}
+	if s := order.Price.String(); s != "-0.150" {
+		t.Fatalf("JSON: wanted %q, got %q", "-0.150", s)
+	}
+}
+
+func TestValue_Concurrent(t *testing.T) {
+	ctx := decimal.Context{Precision: 20}
+	rate := decimal.NewValue(ctx, 1, 0).Quo(decimal.NewValue(ctx, 3, 0))
+	want := rate.Mul(rate).String()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if s := rate.Mul(rate).String(); s != want {
+					t.Errorf("wanted %q, got %q", want, s)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}